@@ -0,0 +1,75 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+)
+
+// Which way an angle turns on its way to the destination.  Counterclockwise
+// is the direction of increasing angle.
+type AngleDirection int
+
+const (
+	ShortestArc AngleDirection = iota
+	Clockwise
+	Counterclockwise
+)
+
+// Interpolates angles in radians.
+func LerpRadians(direction AngleDirection) Lerp[float32] {
+	return func(a, b float32, t float64) float32 {
+		return lerpAngle(a, b, t, 2*math.Pi, direction)
+	}
+}
+
+// Interpolates angles in degrees.
+func LerpDegrees(direction AngleDirection) Lerp[float32] {
+	return func(a, b float32, t float64) float32 {
+		return lerpAngle(a, b, t, 360, direction)
+	}
+}
+
+// Animates an angle in radians.  Intermediate values may fall outside the
+// range of the endpoints, but the final value is always exactly to.
+func NewRadiansAnimation(f ContinuousFunc, from, to float32, direction AngleDirection, target *float32) *Tween[float32] {
+	return NewTween(f, from, to, LerpRadians(direction), target)
+}
+
+// Animates an angle in degrees, as NewRadiansAnimation.
+func NewDegreesAnimation(f ContinuousFunc, from, to float32, direction AngleDirection, target *float32) *Tween[float32] {
+	return NewTween(f, from, to, LerpDegrees(direction), target)
+}
+
+func lerpAngle(a, b float32, t float64, turn float64, direction AngleDirection) float32 {
+	if t == 1 {
+		return b
+	}
+	var delta = math.Mod(float64(b)-float64(a), turn)
+	if delta < 0 {
+		delta += turn
+	}
+	switch direction {
+	case ShortestArc:
+		if delta > turn/2 {
+			delta -= turn
+		}
+	case Clockwise:
+		if delta > 0 {
+			delta -= turn
+		}
+	}
+	return float32(float64(a) + delta*t)
+}
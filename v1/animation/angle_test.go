@@ -0,0 +1,89 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests angle interpolation in each direction.
+func TestLerpDegrees(t *testing.T) {
+	var cases = []struct {
+		from, to  float32
+		direction AngleDirection
+		expected  float32
+	}{
+		{350, 10, ShortestArc, 360},
+		{10, 350, ShortestArc, 0},
+		{10, 350, Clockwise, 0},
+		{10, 350, Counterclockwise, 180},
+		{350, 10, Clockwise, 180},
+		{350, 10, Counterclockwise, 360},
+		{0, 720, Counterclockwise, 0},
+		{-90, 90, ShortestArc, 0},
+	}
+	for _, c := range cases {
+		var lerp = LerpDegrees(c.direction)
+		if got := lerp(c.from, c.to, 0.5); math.Abs(float64(got-c.expected)) > 1e-4 {
+			t.Errorf("Midpoint of %v to %v in direction %v was %v, expected %v", c.from, c.to, c.direction, got, c.expected)
+		}
+		if got := lerp(c.from, c.to, 1); got != c.to {
+			t.Errorf("Angle %v to %v ended at %v", c.from, c.to, got)
+		}
+	}
+}
+
+// Tests that a radians animation takes the shortest arc across zero.
+func TestRadiansAnimation(t *testing.T) {
+	var (
+		dest float32
+		anim = NewRadiansAnimation(LinearFunc(2*time.Second, 0, 1), 0.1, 2*math.Pi-0.1, ShortestArc, &dest)
+	)
+	anim.Update(1 * time.Second)
+	if math.Abs(float64(dest)) > 1e-6 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(1 * time.Second)
+	if dest != 2*math.Pi-0.1 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+}
+
+// Tests that angle and quaternion animations run inside a group.
+func TestRotationAnimationsGrouped(t *testing.T) {
+	var (
+		angle    float32
+		rotation Quat
+		f        = LinearFunc(1*time.Second, 0, 1)
+		axis     = Vec3{0, 1, 0}
+		anim     = NewGroupedAnimation([]Animator{
+			NewDegreesAnimation(f, 350, 10, ShortestArc, &angle),
+			NewQuatAnimation(f, QuatIdentity(), QuatFromAxisAngle(axis, 3*math.Pi/4), SlerpQuat, &rotation),
+		})
+	)
+	anim.Update(500 * time.Millisecond)
+	if math.Abs(float64(angle-360)) > 1e-4 {
+		t.Fatalf("Angle does not match expected, got %v", angle)
+	}
+	if !quatNear(rotation, QuatFromAxisAngle(axis, 3*math.Pi/8)) {
+		t.Fatalf("Rotation does not match expected, got %v", rotation)
+	}
+	anim.Update(500 * time.Millisecond)
+	if angle != 10 || !anim.IsDone() {
+		t.Fatalf("Angle does not match expected, got %v", angle)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"slices"
+	"time"
+)
+
+// Identifies an animator added to an AnimationManager.  Handles are never
+// reused, so a handle to a removed animator stays invalid.
+type Handle uint64
+
+type managedAnimator struct {
+	handle   Handle
+	animator Animator
+	tags     []string
+	paused   bool
+	removed  bool
+}
+
+// Owns a set of animators and updates them together.  Animators are removed
+// and deleted once they are done.  Animators may be added, paused and
+// cancelled from callbacks called during Update: additions are first
+// updated on the next call, and deletions wait until the update finishes.
+type AnimationManager struct {
+	entries  []*managedAnimator
+	handles  map[Handle]*managedAnimator
+	last     Handle
+	updating bool
+	added    []*managedAnimator
+	deleted  []Animator
+}
+
+func NewAnimationManager() *AnimationManager {
+	return &AnimationManager{handles: map[Handle]*managedAnimator{}}
+}
+
+// Adds an animator, with tags for controlling it together with others.
+func (m *AnimationManager) Add(animator Animator, tags ...string) Handle {
+	m.last++
+	var entry = &managedAnimator{
+		handle:   m.last,
+		animator: animator,
+		tags:     tags,
+	}
+	m.handles[entry.handle] = entry
+	if m.updating {
+		m.added = append(m.added, entry)
+	} else {
+		m.entries = append(m.entries, entry)
+	}
+	return entry.handle
+}
+
+// Returns the animator for a handle, if it has not been removed.
+func (m *AnimationManager) Animator(handle Handle) (Animator, bool) {
+	if entry, ok := m.handles[handle]; ok {
+		return entry.animator, true
+	}
+	return nil, false
+}
+
+func (m *AnimationManager) Contains(handle Handle) bool {
+	var _, ok = m.handles[handle]
+	return ok
+}
+
+// Returns how many animators are managed.
+func (m *AnimationManager) Len() int {
+	return len(m.handles)
+}
+
+// Returns the handles of animators with a tag, in the order they were added.
+func (m *AnimationManager) Tagged(tag string) []Handle {
+	var handles []Handle
+	m.each(tag, func(entry *managedAnimator) {
+		handles = append(handles, entry.handle)
+	})
+	return handles
+}
+
+// Calls a function for each managed animator with a tag.
+func (m *AnimationManager) each(tag string, f func(entry *managedAnimator)) int {
+	var count = 0
+	for _, list := range [][]*managedAnimator{m.entries, m.added} {
+		for _, entry := range list {
+			if !entry.removed && slices.Contains(entry.tags, tag) {
+				f(entry)
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Stops updating an animator until it is resumed.
+func (m *AnimationManager) Pause(handle Handle) bool {
+	var entry, ok = m.handles[handle]
+	if ok {
+		entry.paused = true
+	}
+	return ok
+}
+
+func (m *AnimationManager) Resume(handle Handle) bool {
+	var entry, ok = m.handles[handle]
+	if ok {
+		entry.paused = false
+	}
+	return ok
+}
+
+func (m *AnimationManager) IsPaused(handle Handle) bool {
+	var entry, ok = m.handles[handle]
+	return ok && entry.paused
+}
+
+// Removes and deletes an animator before it is done.
+func (m *AnimationManager) Cancel(handle Handle) bool {
+	var entry, ok = m.handles[handle]
+	if ok {
+		m.remove(entry)
+		m.settle()
+	}
+	return ok
+}
+
+// Pauses every animator with a tag, returning how many there were.
+func (m *AnimationManager) PauseTag(tag string) int {
+	return m.each(tag, func(entry *managedAnimator) { entry.paused = true })
+}
+
+func (m *AnimationManager) ResumeTag(tag string) int {
+	return m.each(tag, func(entry *managedAnimator) { entry.paused = false })
+}
+
+// Cancels every animator with a tag, returning how many there were.
+func (m *AnimationManager) CancelTag(tag string) int {
+	var count = m.each(tag, m.remove)
+	m.settle()
+	return count
+}
+
+// Cancels every animator.
+func (m *AnimationManager) Clear() {
+	for _, list := range [][]*managedAnimator{m.entries, m.added} {
+		for _, entry := range list {
+			if !entry.removed {
+				m.remove(entry)
+			}
+		}
+	}
+	m.settle()
+}
+
+// Marks an animator for deletion.
+func (m *AnimationManager) remove(entry *managedAnimator) {
+	entry.removed = true
+	delete(m.handles, entry.handle)
+	m.deleted = append(m.deleted, entry.animator)
+}
+
+// Deletes removed animators straight away unless in the middle of an update.
+func (m *AnimationManager) settle() {
+	if !m.updating {
+		m.flush()
+	}
+}
+
+// Updates every animator which is not paused, in the order they were added,
+// then removes and deletes those which are done.
+func (m *AnimationManager) Update(elapsed time.Duration) {
+	if m.updating {
+		// Called from a callback; the outer update carries on.
+		return
+	}
+	m.step(elapsed)
+	m.flush()
+}
+
+// Updates the animators.  The manager stays usable if one of them panics and
+// the caller recovers.
+func (m *AnimationManager) step(elapsed time.Duration) {
+	m.updating = true
+	defer func() { m.updating = false }()
+	for _, entry := range m.entries {
+		if entry.removed || entry.paused {
+			continue
+		}
+		entry.animator.Update(elapsed)
+		if !entry.removed && entry.animator.IsDone() {
+			m.remove(entry)
+		}
+	}
+}
+
+// Drops removed animators, deletes them, and takes on those added during an
+// update.
+func (m *AnimationManager) flush() {
+	m.entries = slices.DeleteFunc(append(m.entries, m.added...), func(entry *managedAnimator) bool {
+		return entry.removed
+	})
+	m.added = nil
+	for len(m.deleted) > 0 {
+		var animator = m.deleted[0]
+		m.deleted = m.deleted[1:]
+		animator.Delete()
+	}
+}
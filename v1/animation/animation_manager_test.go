@@ -0,0 +1,129 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnimationManager(t *testing.T) {
+	var (
+		manager = NewAnimationManager()
+		short   = NewBoundedAnimation(1 * time.Second)
+		long    = NewBoundedAnimation(2 * time.Second)
+		deleted = 0
+		handle  = manager.Add(short)
+	)
+	manager.Add(long)
+	short.Events().OnDelete(func() { deleted++ })
+	manager.Update(1 * time.Second)
+	if manager.Contains(handle) || deleted != 1 || manager.Len() != 1 {
+		t.Fatalf("AnimationManager did not remove a finished animator")
+	}
+	if long.Elapsed != 1*time.Second {
+		t.Fatalf("AnimationManager did not update every animator")
+	}
+	if _, ok := manager.Animator(handle); ok {
+		t.Fatalf("Removed handle was still valid")
+	}
+	manager.Update(1 * time.Second)
+	if manager.Len() != 0 {
+		t.Fatalf("AnimationManager did not remove the last animator")
+	}
+}
+
+func TestAnimationManagerTags(t *testing.T) {
+	var (
+		manager = NewAnimationManager()
+		enemy1  = NewBoundedAnimation(1 * time.Second)
+		enemy2  = NewBoundedAnimation(1 * time.Second)
+		player  = NewBoundedAnimation(1 * time.Second)
+		handle  = manager.Add(enemy1, "enemy")
+	)
+	manager.Add(enemy2, "enemy", "boss")
+	manager.Add(player, "player")
+	if manager.PauseTag("enemy") != 2 || !manager.IsPaused(handle) {
+		t.Fatalf("AnimationManager did not pause tagged animators")
+	}
+	manager.Update(500 * time.Millisecond)
+	if enemy1.Elapsed != 0 || enemy2.Elapsed != 0 || player.Elapsed != 500*time.Millisecond {
+		t.Fatalf("AnimationManager updated paused animators")
+	}
+	manager.Resume(handle)
+	manager.Update(500 * time.Millisecond)
+	if enemy1.Elapsed != 500*time.Millisecond || enemy2.Elapsed != 0 {
+		t.Fatalf("AnimationManager did not resume a single animator")
+	}
+	if len(manager.Tagged("boss")) != 1 || manager.CancelTag("enemy") != 2 || manager.Len() != 0 {
+		t.Fatalf("AnimationManager did not cancel tagged animators")
+	}
+}
+
+// Tests that callbacks may change the manager while it updates.
+func TestAnimationManagerCallbacks(t *testing.T) {
+	var (
+		manager = NewAnimationManager()
+		first   = NewBoundedAnimation(1 * time.Second)
+		second  = NewBoundedAnimation(1 * time.Second)
+		added   = NewBoundedAnimation(1 * time.Second)
+		handle  Handle
+		deleted = false
+	)
+	first.SetCallback(func() {
+		manager.Cancel(handle)
+		manager.Add(added)
+		manager.Update(1 * time.Second)
+	})
+	manager.Add(first)
+	handle = manager.Add(second)
+	second.Events().OnDelete(func() {
+		deleted = true
+		manager.Clear()
+	})
+	manager.Update(1 * time.Second)
+	if second.Elapsed != 0 || !deleted {
+		t.Fatalf("Cancelled animator was updated or not deleted")
+	}
+	if added.Elapsed != 0 || manager.Len() != 0 {
+		t.Fatalf("Animator added during an update was updated straight away")
+	}
+}
+
+// Tests that the manager recovers from an animator which panics.
+func TestAnimationManagerPanic(t *testing.T) {
+	var (
+		manager  = NewAnimationManager()
+		x        float32
+		panicked bool
+	)
+	manager.Add(NewContinuousAnimation(func(time.Duration) (float32, bool, time.Duration) {
+		if !panicked {
+			panicked = true
+			panic("update failed")
+		}
+		return 0, true, 0
+	}, &x))
+	func() {
+		defer func() { recover() }()
+		manager.Update(100 * time.Millisecond)
+	}()
+	var anim = NewBoundedAnimation(100 * time.Millisecond)
+	manager.Add(anim)
+	manager.Update(100 * time.Millisecond)
+	if !panicked || !anim.IsDone() || manager.Len() != 0 {
+		t.Fatalf("Manager did not update after a panic")
+	}
+}
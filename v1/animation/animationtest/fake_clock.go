@@ -0,0 +1,132 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animationtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pikkpoiss/animation/v1/animation"
+)
+
+// A clock which only moves when told to, for testing code which takes an
+// animation.Clock, such as animation.Runner.
+type FakeClock struct {
+	mutex   sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	var c = &FakeClock{now: start}
+	c.changed = sync.NewCond(&c.mutex)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTicker(interval time.Duration) animation.Ticker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var ticker = &fakeTicker{
+		clock:    c,
+		interval: interval,
+		next:     c.now.Add(interval),
+		ticks:    make(chan time.Time),
+		stopped:  make(chan struct{}),
+	}
+	c.tickers = append(c.tickers, ticker)
+	c.changed.Broadcast()
+	return ticker
+}
+
+// Blocks until at least count tickers are running, so that a test can wait
+// for a goroutine to start ticking before advancing the clock.
+func (c *FakeClock) WaitForTickers(count int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for len(c.tickers) < count {
+		c.changed.Wait()
+	}
+}
+
+// Returns how many tickers are running.
+func (c *FakeClock) Tickers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.tickers)
+}
+
+// Moves the clock on.  Each tick which falls due is delivered in order, at
+// the time it was due, and Advance waits for it to be received unless the
+// ticker is stopped first.
+func (c *FakeClock) Advance(elapsed time.Duration) {
+	c.mutex.Lock()
+	var end = c.now.Add(elapsed)
+	for {
+		var due *fakeTicker
+		for _, ticker := range c.tickers {
+			if !ticker.next.After(end) && (due == nil || ticker.next.Before(due.next)) {
+				due = ticker
+			}
+		}
+		if due == nil {
+			break
+		}
+		var at = due.next
+		c.now = at
+		due.next = at.Add(due.interval)
+		c.mutex.Unlock()
+		select {
+		case due.ticks <- at:
+		case <-due.stopped:
+		}
+		c.mutex.Lock()
+	}
+	c.now = end
+	c.mutex.Unlock()
+}
+
+type fakeTicker struct {
+	clock    *FakeClock
+	interval time.Duration
+	next     time.Time
+	ticks    chan time.Time
+	stopped  chan struct{}
+	once     sync.Once
+}
+
+func (t *fakeTicker) Chan() <-chan time.Time {
+	return t.ticks
+}
+
+func (t *fakeTicker) Stop() {
+	t.once.Do(func() {
+		close(t.stopped)
+		t.clock.mutex.Lock()
+		defer t.clock.mutex.Unlock()
+		for i, ticker := range t.clock.tickers {
+			if ticker == t {
+				t.clock.tickers = append(t.clock.tickers[:i], t.clock.tickers[i+1:]...)
+				break
+			}
+		}
+	})
+}
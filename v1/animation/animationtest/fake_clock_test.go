@@ -0,0 +1,62 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animationtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pikkpoiss/animation/v1/animation"
+)
+
+func TestFakeClockRunner(t *testing.T) {
+	var (
+		clock  = NewFakeClock(time.Unix(0, 0))
+		anim   = animation.NewBoundedAnimation(100 * time.Millisecond)
+		runner = animation.NewRunner(anim, 20*time.Millisecond, clock)
+	)
+	runner.Start(context.Background())
+	clock.WaitForTickers(1)
+	clock.Advance(1 * time.Second)
+	<-runner.Done()
+	if runner.Err() != nil || anim.Elapsed != 100*time.Millisecond {
+		t.Fatalf("Runner did not tick in steps, got %v", anim.Elapsed)
+	}
+	if clock.Now() != time.Unix(1, 0) {
+		t.Fatalf("FakeClock did not advance, got %v", clock.Now())
+	}
+}
+
+func TestFakeClockTicks(t *testing.T) {
+	var (
+		clock  = NewFakeClock(time.Unix(0, 0))
+		ticker = clock.NewTicker(30 * time.Millisecond)
+		ticks  = []time.Duration{}
+		done   = make(chan bool)
+	)
+	go func() {
+		for i := 0; i < 3; i++ {
+			ticks = append(ticks, (<-ticker.Chan()).Sub(time.Unix(0, 0)))
+		}
+		ticker.Stop()
+		done <- true
+	}()
+	clock.Advance(200 * time.Millisecond)
+	<-done
+	if len(ticks) != 3 || ticks[0] != 30*time.Millisecond || ticks[2] != 90*time.Millisecond {
+		t.Fatalf("FakeClock ticked at %v", ticks)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package animationtest helps test animations by playing them in virtual
+// time and recording what they do on each update.
+package animationtest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pikkpoiss/animation/v1/animation"
+)
+
+type value struct {
+	name string
+	read func() string
+}
+
+// Plays an animator in virtual time, recording a Tick for each update.
+type Harness struct {
+	Animator animation.Animator
+	now      time.Duration
+	values   []value
+	calls    []string
+	trace    Trace
+}
+
+func New(animator animation.Animator) *Harness {
+	return &Harness{Animator: animator}
+}
+
+// Returns the virtual time played so far.
+func (h *Harness) Now() time.Duration {
+	return h.now
+}
+
+// Records a value on every tick.
+func (h *Harness) Value(name string, read func() string) *Harness {
+	h.values = append(h.values, value{name, read})
+	return h
+}
+
+func (h *Harness) Float(name string, target *float32) *Harness {
+	return h.Value(name, func() string { return fmt.Sprint(*target) })
+}
+
+func (h *Harness) Int(name string, target *int) *Harness {
+	return h.Value(name, func() string { return fmt.Sprint(*target) })
+}
+
+// Returns a callback which records a call in the trace.
+func (h *Harness) Callback(name string) animation.AnimatorCallback {
+	return func() {
+		h.calls = append(h.calls, name)
+	}
+}
+
+// Records every lifecycle event raised by the animator, except updates,
+// which are recorded anyway.
+func (h *Harness) Events(prefix string, events *animation.Events) *Harness {
+	for _, e := range []struct {
+		name  string
+		event animation.Event
+	}{
+		{"start", animation.EventStart},
+		{"loop", animation.EventLoop},
+		{"complete", animation.EventComplete},
+		{"reset", animation.EventReset},
+		{"delete", animation.EventDelete},
+	} {
+		events.On(e.event, h.Callback(prefix+e.name))
+	}
+	return h
+}
+
+// Updates the animator once, recording the result.
+func (h *Harness) Advance(elapsed time.Duration) Tick {
+	h.calls = nil
+	var remainder = h.Animator.Update(elapsed)
+	h.now += elapsed
+	var tick = Tick{
+		Time:      h.now,
+		Elapsed:   elapsed,
+		Remainder: remainder,
+		Done:      h.Animator.IsDone(),
+		Calls:     h.calls,
+	}
+	for _, v := range h.values {
+		tick.Values = append(tick.Values, Value{v.name, v.read()})
+	}
+	h.calls = nil
+	h.trace = append(h.trace, tick)
+	return tick
+}
+
+// Updates the animator in steps until a total amount of time has been played
+// or it is done.  The last step is shortened to end exactly at the total.
+func (h *Harness) Run(step, total time.Duration) Trace {
+	var (
+		start = len(h.trace)
+		end   = h.now + total
+	)
+	for h.now < end {
+		h.Advance(min(step, end-h.now))
+		if h.Animator.IsDone() {
+			break
+		}
+	}
+	return h.trace[start:]
+}
+
+// Returns every tick recorded so far.
+func (h *Harness) Trace() Trace {
+	return h.trace
+}
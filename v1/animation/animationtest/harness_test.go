@@ -0,0 +1,60 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animationtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pikkpoiss/animation/v1/animation"
+)
+
+func TestFrameAnimationTrace(t *testing.T) {
+	var (
+		target  int
+		frames  = []animation.Frame{animation.MsFrame(100, 0), animation.MsFrame(100, 2), animation.MsFrame(100, 1)}
+		anim    = animation.NewFrameAnimation(frames, false, &target)
+		harness = New(anim).Int("frame", &target).Events("", anim.Events())
+	)
+	anim.SetCallback(harness.Callback("callback"))
+	harness.Run(75*time.Millisecond, 1*time.Second).Compare(t, "frame_animation")
+}
+
+func TestChainedAnimationTrace(t *testing.T) {
+	var (
+		x       float32
+		child1  = animation.NewContinuousAnimation(animation.LinearFunc(200*time.Millisecond, 0, 10), &x)
+		child2  = animation.NewBoundedAnimation(100 * time.Millisecond)
+		anim    = animation.NewChainedAnimation([]animation.Animator{child1, child2}, true)
+		harness = New(anim).Float("x", &x).Events("chain.", anim.Events())
+	)
+	harness.Events("child2.", child2.Events())
+	harness.Run(50*time.Millisecond, 400*time.Millisecond).Compare(t, "chained_animation")
+	if harness.Now() != 400*time.Millisecond || len(harness.Trace()) != 8 {
+		t.Fatalf("Harness played for %v", harness.Now())
+	}
+}
+
+func TestHarnessAdvance(t *testing.T) {
+	var (
+		anim    = animation.NewBoundedAnimation(100 * time.Millisecond)
+		harness = New(anim)
+	)
+	anim.SetCallback(harness.Callback("done"))
+	var tick = harness.Advance(150 * time.Millisecond)
+	if tick.String() != "150ms +150ms remainder=50ms done=true calls=[done]" {
+		t.Fatalf("Tick was %v", tick)
+	}
+}
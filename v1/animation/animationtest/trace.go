@@ -0,0 +1,110 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animationtest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Named after the package so that it does not clash with flags defined by
+// the tests using it.
+var update = flag.Bool("animationtest.update", false, "rewrite golden files with the traces produced")
+
+// A named value recorded on a tick.
+type Value struct {
+	Name  string
+	Value string
+}
+
+// What happened during one update.
+type Tick struct {
+	Time      time.Duration
+	Elapsed   time.Duration
+	Remainder time.Duration
+	Done      bool
+	Values    []Value
+	Calls     []string
+}
+
+// Formats a tick as one line, for example
+//
+//	250ms +100ms remainder=0s done=false x=2.5 calls=[start]
+func (t Tick) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v +%v remainder=%v done=%v", t.Time, t.Elapsed, t.Remainder, t.Done)
+	for _, v := range t.Values {
+		fmt.Fprintf(&b, " %v=%v", v.Name, v.Value)
+	}
+	if len(t.Calls) > 0 {
+		fmt.Fprintf(&b, " calls=[%v]", strings.Join(t.Calls, " "))
+	}
+	return b.String()
+}
+
+type Trace []Tick
+
+func (t Trace) String() string {
+	var b strings.Builder
+	for _, tick := range t {
+		b.WriteString(tick.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Compares the trace with testdata/<name>.golden, failing the test on the
+// first line which differs.  Run the test with -animationtest.update to write the file.
+func (t Trace) Compare(tb testing.TB, name string) {
+	tb.Helper()
+	var (
+		path   = filepath.Join("testdata", name+".golden")
+		actual = t.String()
+	)
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			tb.Fatalf("Could not create testdata: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(actual), 0644); err != nil {
+			tb.Fatalf("Could not update golden file: %v", err)
+		}
+		return
+	}
+	var expected, err = os.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("Could not read golden file (run with -animationtest.update to create it): %v", err)
+	}
+	var (
+		want = strings.Split(string(expected), "\n")
+		got  = strings.Split(actual, "\n")
+	)
+	for i := 0; i < max(len(want), len(got)); i++ {
+		var w, g string
+		if i < len(want) {
+			w = want[i]
+		}
+		if i < len(got) {
+			g = got[i]
+		}
+		if w != g {
+			tb.Fatalf("Trace differs from %v at line %v:\n got: %v\nwant: %v", path, i+1, g, w)
+		}
+	}
+}
@@ -21,9 +21,90 @@ import (
 type AnimatorCallback func()
 
 type Animator interface {
+	// Sets a callback which is called once each time the animator becomes
+	// done.  Observable animators raise more events, to more subscribers.
 	SetCallback(callback AnimatorCallback)
 	IsDone() bool
 	Update(elapsed time.Duration) time.Duration
 	Reset()
 	Delete()
 }
+
+// Controls whether callbacks crossed while seeking are called.
+type SeekMode int
+
+const (
+	SeekSilent SeekMode = iota
+	SeekReplay
+)
+
+// Implemented by animators which can jump directly to a position, for
+// example to scrub through an animation in an editor.  Seek returns any time
+// past the end of the animation, as Update does.
+type Seeker interface {
+	Seek(position time.Duration, mode SeekMode) time.Duration
+	Position() time.Duration
+}
+
+// Seeks an animator.  Animators which are not Seekers are reset and then
+// updated to the position, which always calls their callbacks.
+func SeekAnimator(animator Animator, position time.Duration, mode SeekMode) time.Duration {
+	if seeker, ok := animator.(Seeker); ok {
+		return seeker.Seek(position, mode)
+	}
+	animator.Reset()
+	if position <= 0 {
+		return 0
+	}
+	return animator.Update(position)
+}
+
+// Returns the position of an animator, or zero if it is not a Seeker.
+func PositionOf(animator Animator) time.Duration {
+	if seeker, ok := animator.(Seeker); ok {
+		return seeker.Position()
+	}
+	return 0
+}
+
+// Implemented by animators which know ahead of time how long a single pass
+// takes.
+type Measurable interface {
+	Length() time.Duration
+}
+
+// Implemented by animators which can only move backwards when the animators
+// they contain can.
+type rewinder interface {
+	canRewind() bool
+}
+
+// Reports whether negative elapsed time can move an animator back, which
+// needs it and everything it contains to be Measurable Seekers.
+func canRewind(animator Animator) bool {
+	if r, ok := animator.(rewinder); ok {
+		return r.canRewind()
+	}
+	var (
+		_, seeks    = animator.(Seeker)
+		_, measures = animator.(Measurable)
+	)
+	return seeks && measures
+}
+
+func canRewindAll(animators []Animator) bool {
+	for _, animator := range animators {
+		if !canRewind(animator) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the length of an animator, or zero if it cannot be measured.
+func LengthOf(animator Animator) time.Duration {
+	if m, ok := animator.(Measurable); ok {
+		return m.Length()
+	}
+	return 0
+}
@@ -27,5 +27,31 @@ func TestInterfaces(t *testing.T) {
 	anim = NewGroupedAnimation([]Animator{})
 	anim = NewFrameAnimation([]Frame{}, false, nil)
 	anim = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), nil)
+	anim = NewSpringAnimation(0, 10, nil)
+	anim = NewTween(LinearFunc(1*time.Second, 0, 1), 0, 10, LerpFloat[float64], nil)
+	anim = NewKeyframeTrack([]Keyframe{}, false, nil)
+	anim = NewTimeline()
+	anim = NewTimeScaled(NewBoundedAnimation(1*time.Second), 0.5)
+	anim = NewPausableAnimation(NewBoundedAnimation(1 * time.Second))
+	anim = Repeat(NewBoundedAnimation(1*time.Second), 2)
+	anim = Delay(1*time.Second, NewBoundedAnimation(1*time.Second))
 	t.Logf("Done checking interfaces for %v", anim)
 }
+
+// Tests that animators which cannot seek are replayed from the start.
+func TestSeekAnimatorFallback(t *testing.T) {
+	var (
+		dest float32
+		anim = NewSpringAnimation(0, 10, &dest)
+	)
+	anim.Update(200 * time.Millisecond)
+	var expected = dest
+	anim.Update(1 * time.Second)
+	SeekAnimator(anim, 200*time.Millisecond, SeekSilent)
+	if dest != expected {
+		t.Fatalf("SeekAnimator did not replay to position, got %v", dest)
+	}
+	if PositionOf(anim) != 0 {
+		t.Fatalf("PositionOf returned a position for a non-Seeker")
+	}
+}
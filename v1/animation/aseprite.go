@@ -0,0 +1,151 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"slices"
+)
+
+// A sprite sheet exported by Aseprite with --data.  Frames are numbered in
+// the order they appear in the file, and that number is used as the Index of
+// each Frame.
+type AsepriteSheet struct {
+	Frames []Frame
+	Bounds []image.Rectangle
+	Tags   []AsepriteTag
+}
+
+// A named range of frames.  Direction is "forward", "reverse", "pingpong"
+// or "pingpong_reverse".
+type AsepriteTag struct {
+	Name      string
+	From      int
+	To        int
+	Direction string
+}
+
+type asepriteFrame struct {
+	Frame struct {
+		X, Y, W, H int
+	}
+	Duration int
+}
+
+type asepriteFile struct {
+	Frames json.RawMessage
+	Meta   struct {
+		FrameTags []struct {
+			Name      string
+			From      int
+			To        int
+			Direction string
+		}
+	}
+}
+
+// Parses the JSON data file written by Aseprite, in either its hash or its
+// array layout.
+func ParseAseprite(data []byte) (*AsepriteSheet, error) {
+	var (
+		file   asepriteFile
+		frames []asepriteFrame
+		err    error
+	)
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if _, frames, err = decodeSheetFrames[asepriteFrame](file.Frames); err != nil {
+		return nil, err
+	}
+	var sheet = &AsepriteSheet{}
+	for i, frame := range frames {
+		if frame.Duration <= 0 {
+			return nil, fmt.Errorf("aseprite frame %v has duration %v", i, frame.Duration)
+		}
+		var bounds = image.Rect(frame.Frame.X, frame.Frame.Y, frame.Frame.X+frame.Frame.W, frame.Frame.Y+frame.Frame.H)
+		sheet.Frames = append(sheet.Frames, MsFrame(frame.Duration, i))
+		sheet.Bounds = append(sheet.Bounds, bounds)
+	}
+	for _, tag := range file.Meta.FrameTags {
+		if tag.From < 0 || tag.To < tag.From || tag.To >= len(frames) {
+			return nil, fmt.Errorf("aseprite tag %q has invalid frames %v to %v", tag.Name, tag.From, tag.To)
+		}
+		if _, ok := asepriteDirections[tag.Direction]; !ok {
+			return nil, fmt.Errorf("aseprite tag %q has unknown direction %q", tag.Name, tag.Direction)
+		}
+		sheet.Tags = append(sheet.Tags, AsepriteTag{tag.Name, tag.From, tag.To, tag.Direction})
+	}
+	return sheet, nil
+}
+
+// Ping-pong tags are played forwards as one explicit round trip, since
+// Aseprite shows the end frames once per visit.
+var asepriteDirections = map[string]Direction{
+	"":                 Forward,
+	"forward":          Forward,
+	"reverse":          Reverse,
+	"pingpong":         Forward,
+	"pingpong_reverse": Forward,
+}
+
+// Returns the frames of a tag in the order they first play.  Ping-pong tags
+// return a whole round trip, such as 1 2 3 2, which then repeats.
+func (s *AsepriteSheet) TagFrames(tag AsepriteTag) []Frame {
+	var frames = slices.Clone(s.Frames[tag.From : tag.To+1])
+	switch tag.Direction {
+	case "pingpong":
+		frames = bounce(frames)
+	case "pingpong_reverse":
+		slices.Reverse(frames)
+		frames = bounce(frames)
+	}
+	return frames
+}
+
+// Appends the way back to a run of frames, leaving out both ends.
+func bounce(frames []Frame) []Frame {
+	for i := len(frames) - 2; i > 0; i-- {
+		frames = append(frames, frames[i])
+	}
+	return frames
+}
+
+// Returns the animation for a tag, playing in the tag's direction.
+func (s *AsepriteSheet) Animation(name string, loop bool, target *int) (*FrameAnimation, error) {
+	for _, tag := range s.Tags {
+		if tag.Name == name {
+			return s.tagAnimation(tag, loop, target), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown aseprite tag %q", name)
+}
+
+// Returns an animation for each tag, keyed by name.
+func (s *AsepriteSheet) Animations(loop bool, target *int) map[string]*FrameAnimation {
+	var animations = map[string]*FrameAnimation{}
+	for _, tag := range s.Tags {
+		animations[tag.Name] = s.tagAnimation(tag, loop, target)
+	}
+	return animations
+}
+
+func (s *AsepriteSheet) tagAnimation(tag AsepriteTag, loop bool, target *int) *FrameAnimation {
+	var anim = NewFrameAnimation(s.TagFrames(tag), loop, target)
+	anim.SetDirection(asepriteDirections[tag.Direction])
+	return anim
+}
@@ -0,0 +1,128 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"fmt"
+	"image"
+	"testing"
+	"time"
+)
+
+const asepriteHash = `{
+	"frames": {
+		"knight 2.aseprite": {"frame": {"x": 32, "y": 0, "w": 16, "h": 16}, "duration": 100},
+		"knight 0.aseprite": {"frame": {"x": 0, "y": 0, "w": 16, "h": 16}, "duration": 100},
+		"knight 1.aseprite": {"frame": {"x": 16, "y": 0, "w": 16, "h": 16}, "duration": 150},
+		"knight 3.aseprite": {"frame": {"x": 48, "y": 0, "w": 16, "h": 16}, "duration": 50}
+	},
+	"meta": {
+		"app": "https://www.aseprite.org/",
+		"frameTags": [
+			{"name": "idle", "from": 0, "to": 1, "direction": "forward"},
+			{"name": "walk", "from": 1, "to": 3, "direction": "pingpong"},
+			{"name": "fall", "from": 2, "to": 3, "direction": "reverse"}
+		]
+	}
+}`
+
+const asepriteArray = `{
+	"frames": [
+		{"filename": "a", "frame": {"x": 0, "y": 0, "w": 8, "h": 8}, "duration": 100},
+		{"filename": "b", "frame": {"x": 8, "y": 0, "w": 8, "h": 8}, "duration": 200}
+	],
+	"meta": {"frameTags": [{"name": "blink", "from": 0, "to": 1, "direction": "pingpong_reverse"}]}
+}`
+
+func TestParseAseprite(t *testing.T) {
+	var (
+		sheet *AsepriteSheet
+		err   error
+	)
+	if sheet, err = ParseAseprite([]byte(asepriteHash)); err != nil {
+		t.Fatalf("Could not parse aseprite data: %v", err)
+	}
+	if len(sheet.Frames) != 4 || len(sheet.Tags) != 3 {
+		t.Fatalf("Expected 4 frames and 3 tags, got %v and %v", len(sheet.Frames), len(sheet.Tags))
+	}
+	if sheet.Frames[2].Duration != 150*time.Millisecond || sheet.Frames[2].Index != 2 {
+		t.Fatalf("Frames should keep file order, got %v", sheet.Frames[2])
+	}
+	if sheet.Bounds[0] != image.Rect(32, 0, 48, 16) {
+		t.Fatalf("Unexpected bounds %v", sheet.Bounds[0])
+	}
+}
+
+func TestAsepriteAnimations(t *testing.T) {
+	var (
+		frame      int
+		sheet, _   = ParseAseprite([]byte(asepriteHash))
+		animations = sheet.Animations(false, &frame)
+		walk       = animations["walk"]
+		fall       = animations["fall"]
+	)
+	if len(animations) != 3 || walk.Length() != 450*time.Millisecond {
+		t.Fatalf("Walk should ping pong over 450ms, got %v", walk.Length())
+	}
+	var walked = []int{}
+	for i := 0; i < 8; i++ {
+		walk.Update(50 * time.Millisecond)
+		walked = append(walked, frame)
+	}
+	if fmt.Sprint(walked) != "[1 2 2 2 3 2 2 2]" {
+		t.Fatalf("Walk played frames %v", walked)
+	}
+	fall.Update(10 * time.Millisecond)
+	if frame != 3 || fall.Length() != 200*time.Millisecond {
+		t.Fatalf("Fall should start on its last frame, got %v", frame)
+	}
+	if _, err := sheet.Animation("jump", false, &frame); err == nil {
+		t.Fatalf("Expected an error for an unknown tag")
+	}
+}
+
+func TestAsepriteArray(t *testing.T) {
+	var (
+		frame    int
+		sheet, _ = ParseAseprite([]byte(asepriteArray))
+		blink, _ = sheet.Animation("blink", true, &frame)
+	)
+	blink.Update(100 * time.Millisecond)
+	if frame != 1 {
+		t.Fatalf("Reversed ping pong should start on the last frame, got %v", frame)
+	}
+	blink.Update(150 * time.Millisecond)
+	if frame != 0 {
+		t.Fatalf("Expected frame 0, got %v", frame)
+	}
+	blink.Update(100 * time.Millisecond)
+	if frame != 1 || blink.Length() != 300*time.Millisecond {
+		t.Fatalf("Ping pong should show each end once per visit, got %v", frame)
+	}
+}
+
+func TestParseAsepriteErrors(t *testing.T) {
+	var tests = []string{
+		`{"meta": {}}`,
+		`{"frames": [{"duration": 0}]}`,
+		`{"frames": [{"duration": 100}], "meta": {"frameTags": [{"name": "a", "from": 0, "to": 1}]}}`,
+		`{"frames": [{"duration": 100}], "meta": {"frameTags": [{"name": "a", "from": 0, "to": 0, "direction": "sideways"}]}}`,
+	}
+	for _, test := range tests {
+		if _, err := ParseAseprite([]byte(test)); err == nil {
+			t.Fatalf("Expected an error for %v", test)
+		}
+	}
+}
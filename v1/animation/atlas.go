@@ -0,0 +1,231 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The frame rate of atlas animations unless it is changed.
+const DefaultAtlasFPS = 12
+
+// A texture atlas exported by TexturePacker in its JSON hash or JSON array
+// format.  Frames named with a numeric suffix, such as "run_00.png" to
+// "run_11.png", form an animation named by their prefix, "run".  Frames
+// without a suffix, or named only by a number, are animations of a single
+// frame.  The position of a frame in the file is used as its Index.
+type Atlas struct {
+	FPS       float64
+	Names     []string
+	Bounds    []image.Rectangle
+	sequences map[string][]int
+	rates     map[string]float64
+}
+
+type texturePackerFrame struct {
+	Filename string
+	Frame    struct {
+		X, Y, W, H int
+	}
+	Rotated bool
+}
+
+// Parses an atlas written by TexturePacker.
+func ParseTexturePacker(data []byte) (*Atlas, error) {
+	var (
+		file struct {
+			Frames json.RawMessage
+		}
+		names  []string
+		frames []texturePackerFrame
+		err    error
+	)
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if names, frames, err = decodeSheetFrames[texturePackerFrame](file.Frames); err != nil {
+		return nil, err
+	}
+	var atlas = &Atlas{
+		FPS:       DefaultAtlasFPS,
+		sequences: map[string][]int{},
+		rates:     map[string]float64{},
+	}
+	for i, frame := range frames {
+		var (
+			name = frame.Filename
+			w, h = frame.Frame.W, frame.Frame.H
+		)
+		if name == "" {
+			name = names[i]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("atlas frame %v has no name", i)
+		}
+		// Rotated frames are stored turned by 90 degrees in the sheet.
+		if frame.Rotated {
+			w, h = h, w
+		}
+		atlas.Names = append(atlas.Names, name)
+		atlas.Bounds = append(atlas.Bounds, image.Rect(frame.Frame.X, frame.Frame.Y, frame.Frame.X+w, frame.Frame.Y+h))
+	}
+	atlas.group()
+	return atlas, nil
+}
+
+var atlasSuffix = regexp.MustCompile(`^(.*?)[_\-. ]?(\d+)$`)
+
+// Splits a frame name into its animation name and its number within it.
+func atlasFrameName(name string) (string, int) {
+	name = strings.TrimSuffix(name, path.Ext(name))
+	var match = atlasSuffix.FindStringSubmatch(name)
+	if match == nil {
+		return name, 0
+	}
+	var number, err = strconv.Atoi(match[2])
+	if err != nil || match[1] == "" {
+		return name, 0
+	}
+	return match[1], number
+}
+
+// Groups frames into animations, ordered by their numbers.
+func (a *Atlas) group() {
+	var numbers = make([]int, len(a.Names))
+	for i, name := range a.Names {
+		var sequence string
+		sequence, numbers[i] = atlasFrameName(name)
+		a.sequences[sequence] = append(a.sequences[sequence], i)
+	}
+	for _, indices := range a.sequences {
+		sort.SliceStable(indices, func(i, j int) bool {
+			return numbers[indices[i]] < numbers[indices[j]]
+		})
+	}
+}
+
+// Returns the names of the animations in the atlas, sorted.
+func (a *Atlas) AnimationNames() []string {
+	var names = make([]string, 0, len(a.sequences))
+	for name := range a.sequences {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Overrides the frame rate of one animation.
+func (a *Atlas) SetFPS(name string, fps float64) {
+	a.rates[name] = fps
+}
+
+// Returns the frame rate of an animation.
+func (a *Atlas) FPSOf(name string) float64 {
+	if fps, ok := a.rates[name]; ok {
+		return fps
+	}
+	return a.FPS
+}
+
+// Returns the frames of an animation at its frame rate.
+func (a *Atlas) AnimationFrames(name string) ([]Frame, error) {
+	var indices, ok = a.sequences[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown atlas animation %q", name)
+	}
+	var fps = a.FPSOf(name)
+	if fps <= 0 {
+		return nil, fmt.Errorf("atlas animation %q has frame rate %v", name, fps)
+	}
+	var (
+		frames   = make([]Frame, 0, len(indices))
+		duration = time.Duration(float64(time.Second) / fps)
+	)
+	for _, index := range indices {
+		frames = append(frames, Frame{duration, index})
+	}
+	return frames, nil
+}
+
+// Returns the animation for a group of frames.
+func (a *Atlas) Animation(name string, loop bool, target *int) (*FrameAnimation, error) {
+	var frames, err = a.AnimationFrames(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewFrameAnimation(frames, loop, target), nil
+}
+
+// Returns an animation for each group of frames, keyed by name.
+func (a *Atlas) Animations(loop bool, target *int) (map[string]*FrameAnimation, error) {
+	var animations = map[string]*FrameAnimation{}
+	for name := range a.sequences {
+		var (
+			anim *FrameAnimation
+			err  error
+		)
+		if anim, err = a.Animation(name, loop, target); err != nil {
+			return nil, err
+		}
+		animations[name] = anim
+	}
+	return animations, nil
+}
+
+// Decodes the frames of a sprite sheet, which tools write either as an
+// object keyed by name, in frame order, or as an array.  The object is read
+// token by token to keep that order.  Names are empty for arrays.
+func decodeSheetFrames[T any](data json.RawMessage) (names []string, frames []T, err error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("sprite sheet has no frames")
+	}
+	if data[0] == '[' {
+		if err = json.Unmarshal(data, &frames); err != nil {
+			return nil, nil, err
+		}
+		return make([]string, len(frames)), frames, nil
+	}
+	var (
+		decoder = json.NewDecoder(bytes.NewReader(data))
+		token   json.Token
+	)
+	if token, err = decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("sprite sheet frames should be an object or an array")
+	}
+	for decoder.More() {
+		var frame T
+		if token, err = decoder.Token(); err != nil {
+			return nil, nil, err
+		}
+		if err = decoder.Decode(&frame); err != nil {
+			return nil, nil, err
+		}
+		names = append(names, token.(string))
+		frames = append(frames, frame)
+	}
+	return names, frames, nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"image"
+	"slices"
+	"testing"
+	"time"
+)
+
+const texturePackerHash = `{
+	"frames": {
+		"hero/run_02.png": {"frame": {"x": 64, "y": 0, "w": 32, "h": 48}, "rotated": false},
+		"hero/run_00.png": {"frame": {"x": 0, "y": 0, "w": 32, "h": 48}, "rotated": false},
+		"hero/run_01.png": {"frame": {"x": 32, "y": 0, "w": 32, "h": 48}, "rotated": true},
+		"hero/idle.png": {"frame": {"x": 96, "y": 0, "w": 32, "h": 48}, "rotated": false},
+		"jump-10.png": {"frame": {"x": 0, "y": 48, "w": 32, "h": 48}, "rotated": false},
+		"jump-9.png": {"frame": {"x": 32, "y": 48, "w": 32, "h": 48}, "rotated": false}
+	},
+	"meta": {"app": "https://www.codeandweb.com/texturepacker", "image": "hero.png"}
+}`
+
+const texturePackerArray = `{
+	"frames": [
+		{"filename": "coin 1", "frame": {"x": 0, "y": 0, "w": 8, "h": 8}},
+		{"filename": "coin 0", "frame": {"x": 8, "y": 0, "w": 8, "h": 8}},
+		{"filename": "01.png", "frame": {"x": 16, "y": 0, "w": 8, "h": 8}},
+		{"filename": "02.png", "frame": {"x": 24, "y": 0, "w": 8, "h": 8}}
+	]
+}`
+
+func TestParseTexturePacker(t *testing.T) {
+	var (
+		atlas *Atlas
+		err   error
+	)
+	if atlas, err = ParseTexturePacker([]byte(texturePackerHash)); err != nil {
+		t.Fatalf("Could not parse atlas: %v", err)
+	}
+	if !slices.Equal(atlas.AnimationNames(), []string{"hero/idle", "hero/run", "jump"}) {
+		t.Fatalf("Unexpected animations %v", atlas.AnimationNames())
+	}
+	if atlas.Names[0] != "hero/run_02.png" || atlas.Bounds[2] != image.Rect(32, 0, 80, 32) {
+		t.Fatalf("Rotated frames should be turned in the sheet, got %v", atlas.Bounds[2])
+	}
+	var (
+		indices   = []int{}
+		frames, _ = atlas.AnimationFrames("hero/run")
+	)
+	for _, frame := range frames {
+		indices = append(indices, frame.Index)
+	}
+	if !slices.Equal(indices, []int{1, 2, 0}) {
+		t.Fatalf("Frames should be ordered by number, got %v", indices)
+	}
+	if frames, _ := atlas.AnimationFrames("jump"); frames[0].Index != 5 || frames[1].Index != 4 {
+		t.Fatalf("Frame numbers should compare as numbers, got %v", frames)
+	}
+}
+
+func TestAtlasAnimations(t *testing.T) {
+	var (
+		frame    int
+		atlas, _ = ParseTexturePacker([]byte(texturePackerHash))
+	)
+	atlas.FPS = 10
+	atlas.SetFPS("jump", 4)
+	var animations, err = atlas.Animations(false, &frame)
+	if err != nil || len(animations) != 3 {
+		t.Fatalf("Expected 3 animations, got %v (%v)", len(animations), err)
+	}
+	if animations["hero/run"].Length() != 300*time.Millisecond || animations["jump"].Length() != 500*time.Millisecond {
+		t.Fatalf("Unexpected lengths %v and %v", animations["hero/run"].Length(), animations["jump"].Length())
+	}
+	animations["hero/run"].Update(150 * time.Millisecond)
+	if frame != 2 {
+		t.Fatalf("Expected frame 2, got %v", frame)
+	}
+	atlas.SetFPS("jump", 0)
+	if _, err = atlas.Animation("jump", false, &frame); err == nil {
+		t.Fatalf("Expected an error for a zero frame rate")
+	}
+	atlas.SetFPS("hero/idle", -1)
+	if _, err = atlas.AnimationFrames("hero/idle"); err == nil {
+		t.Fatalf("Expected an error for a negative frame rate")
+	}
+	if _, err = atlas.Animation("fly", false, &frame); err == nil {
+		t.Fatalf("Expected an error for an unknown animation")
+	}
+}
+
+func TestTexturePackerArray(t *testing.T) {
+	var atlas, err = ParseTexturePacker([]byte(texturePackerArray))
+	if err != nil {
+		t.Fatalf("Could not parse atlas: %v", err)
+	}
+	if frames, _ := atlas.AnimationFrames("coin"); len(frames) != 2 || frames[0].Index != 1 || frames[0].Duration != time.Second/DefaultAtlasFPS {
+		t.Fatalf("Unexpected coin frames %v", frames)
+	}
+	if !slices.Equal(atlas.AnimationNames(), []string{"01", "02", "coin"}) {
+		t.Fatalf("Frames named by a number should stand alone, got %v", atlas.AnimationNames())
+	}
+}
@@ -19,37 +19,84 @@ import (
 )
 
 type BoundedAnimation struct {
-	Elapsed  time.Duration
-	Duration time.Duration
-	callback AnimatorCallback
+	Elapsed   time.Duration
+	Duration  time.Duration
+	events    *Events
+	direction Direction
+	markers   *markerSet
 }
 
 func NewBoundedAnimation(duration time.Duration) *BoundedAnimation {
-	return &BoundedAnimation{0, duration, nil}
+	return &BoundedAnimation{0, duration, &Events{}, Forward, nil}
 }
 
 func (a *BoundedAnimation) SetCallback(callback AnimatorCallback) {
-	a.callback = callback
+	a.Events().setCallback(callback)
+}
+
+func (a *BoundedAnimation) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+// Calls a callback when the animation reaches an offset from its start.
+// Markers are called once per pass, in order, even when one update jumps
+// past several of them, and are armed again by Reset.
+func (a *BoundedAnimation) AddMarker(name string, offset time.Duration, callback AnimatorCallback) Subscription {
+	return ensureMarkers(&a.markers, a.Elapsed).add(name, offset, callback)
+}
+
+// Returns the offset of a marker.
+func (a *BoundedAnimation) Marker(name string) (time.Duration, bool) {
+	return a.markers.find(name)
 }
 
 func (a *BoundedAnimation) IsDone() bool {
-	return a.Elapsed >= a.Duration
+	return a.Elapsed >= a.Length()
 }
 
+// Negative elapsed time moves the animation back towards its start.
 func (a *BoundedAnimation) Update(elapsed time.Duration) time.Duration {
-	a.Elapsed += elapsed
+	a.Elapsed = max(a.Elapsed+elapsed, 0)
+	a.markers.play(a.Elapsed, a.Duration, a.direction)
+	a.events.update(a.IsDone())
 	if a.IsDone() {
-		if a.callback != nil {
-			a.callback()
-		}
-		return a.Elapsed - a.Duration
+		return a.Elapsed - a.Length()
 	}
 	return 0
 }
 
 func (a *BoundedAnimation) Reset() {
 	a.Elapsed = 0
+	a.markers.reset()
+	a.events.reset()
 }
 
 func (a *BoundedAnimation) Delete() {
+	a.events.delete()
+}
+
+func (a *BoundedAnimation) Seek(position time.Duration, mode SeekMode) time.Duration {
+	a.Elapsed = max(position, 0)
+	a.markers.seek(a.Elapsed, a.Duration, a.direction, mode)
+	a.events.seek(a.IsDone(), mode)
+	if a.IsDone() {
+		return a.Elapsed - a.Length()
+	}
+	return 0
+}
+
+func (a *BoundedAnimation) Position() time.Duration {
+	return a.Elapsed
+}
+
+func (a *BoundedAnimation) Length() time.Duration {
+	return passLength(a.Duration, a.direction)
+}
+
+func (a *BoundedAnimation) SetDirection(direction Direction) {
+	a.direction = direction
+}
+
+func (a *BoundedAnimation) Direction() Direction {
+	return a.direction
 }
@@ -15,6 +15,7 @@
 package animation
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -23,7 +24,7 @@ import (
 func TestBoundedAnimationSetCallback(t *testing.T) {
 	var (
 		done = false
-		anim = BoundedAnimation{0, 1 * time.Second, nil}
+		anim = BoundedAnimation{Duration: 1 * time.Second}
 		cb   = func() { done = true }
 	)
 	anim.SetCallback(cb)
@@ -35,7 +36,7 @@ func TestBoundedAnimationSetCallback(t *testing.T) {
 
 // Tests that BoundedAnimation.IsDone is true when elapsed == duration.
 func TestBoundedAnimationIsDoneElapsedEqDuration(t *testing.T) {
-	var anim = BoundedAnimation{0, 1 * time.Second, nil}
+	var anim = BoundedAnimation{Duration: 1 * time.Second}
 	anim.Update(1 * time.Second)
 	if !anim.IsDone() {
 		t.Fatalf("BoundedAnimation.IsDone was not expected value")
@@ -44,7 +45,7 @@ func TestBoundedAnimationIsDoneElapsedEqDuration(t *testing.T) {
 
 // Tests that BoundedAnimation.IsDone is true when elapsed > duration.
 func TestBoundedAnimationIsDoneElapsedGtDuration(t *testing.T) {
-	var anim = BoundedAnimation{0, 1 * time.Second, nil}
+	var anim = BoundedAnimation{Duration: 1 * time.Second}
 	anim.Update(9 * time.Second)
 	if !anim.IsDone() {
 		t.Fatalf("BoundedAnimation.IsDone was not expected value")
@@ -53,7 +54,7 @@ func TestBoundedAnimationIsDoneElapsedGtDuration(t *testing.T) {
 
 // Tests that BoundedAnimation.IsDone is false when elapsed < duration.
 func TestBoundedAnimationIsDoneElapsedLtDuration(t *testing.T) {
-	var anim = BoundedAnimation{0, 1 * time.Second, nil}
+	var anim = BoundedAnimation{Duration: 1 * time.Second}
 	anim.Update(200 * time.Millisecond)
 	if anim.IsDone() {
 		t.Fatalf("BoundedAnimation.IsDone was not expected value")
@@ -63,7 +64,7 @@ func TestBoundedAnimationIsDoneElapsedLtDuration(t *testing.T) {
 // Tests that BoundedAnimation.Update increments elapsed and returns overflow.
 func TestBoundedAnimationUpdate(t *testing.T) {
 	var (
-		anim               = BoundedAnimation{0, 1 * time.Second, nil}
+		anim               = BoundedAnimation{Duration: 1 * time.Second}
 		resp time.Duration = 0
 	)
 	resp = anim.Update(200 * time.Millisecond)
@@ -90,9 +91,101 @@ func TestBoundedAnimationUpdate(t *testing.T) {
 }
 
 func TestBoundedAnimationReset(t *testing.T) {
-	var anim = BoundedAnimation{200 * time.Millisecond, 1 * time.Second, nil}
+	var anim = BoundedAnimation{Elapsed: 200 * time.Millisecond, Duration: 1 * time.Second}
 	anim.Reset()
 	if anim.Elapsed != 0 {
 		t.Fatalf("BoundedAnimation.Elapsed was not expected value")
 	}
 }
+
+// Tests that seeking sets the position and only replays callbacks on request.
+func TestBoundedAnimationSeek(t *testing.T) {
+	var (
+		calls = 0
+		anim  = NewBoundedAnimation(1 * time.Second)
+		resp  time.Duration
+	)
+	anim.SetCallback(func() { calls++ })
+	resp = anim.Seek(1500*time.Millisecond, SeekSilent)
+	if anim.Position() != 1500*time.Millisecond || resp != 500*time.Millisecond {
+		t.Fatalf("BoundedAnimation.Seek did not move to position")
+	}
+	if !anim.IsDone() || calls != 0 {
+		t.Fatalf("BoundedAnimation.Seek called callback in silent mode")
+	}
+	anim.Seek(200*time.Millisecond, SeekReplay)
+	if anim.IsDone() || calls != 0 {
+		t.Fatalf("BoundedAnimation.Seek backwards did not undo completion")
+	}
+	anim.Seek(1*time.Second, SeekReplay)
+	if calls != 1 {
+		t.Fatalf("BoundedAnimation.Seek did not replay crossed callback")
+	}
+}
+
+// Tests that a ping-pong animation lasts for both legs and negative time rewinds.
+func TestBoundedAnimationDirection(t *testing.T) {
+	var anim = NewBoundedAnimation(1 * time.Second)
+	anim.SetDirection(PingPong)
+	if anim.Length() != 2*time.Second {
+		t.Fatalf("BoundedAnimation.Length was %v", anim.Length())
+	}
+	anim.Update(1500 * time.Millisecond)
+	if anim.IsDone() {
+		t.Fatalf("Ping-pong BoundedAnimation marked done too early")
+	}
+	if anim.Update(-2*time.Second) != 0 || anim.Elapsed != 0 {
+		t.Fatalf("Negative update did not clamp at the start, got %v", anim.Elapsed)
+	}
+	if anim.Update(2100*time.Millisecond) != 100*time.Millisecond || !anim.IsDone() {
+		t.Fatalf("Ping-pong BoundedAnimation did not finish after both legs")
+	}
+}
+
+// Tests that markers are called once each, in order, and armed by Reset.
+func TestBoundedAnimationMarkers(t *testing.T) {
+	var (
+		anim = NewBoundedAnimation(1 * time.Second)
+		seen = []string{}
+	)
+	anim.AddMarker("particles", 350*time.Millisecond, func() { seen = append(seen, "particles") })
+	anim.AddMarker("start", 0, func() { seen = append(seen, "start") })
+	anim.AddMarker("end", 1*time.Second, func() { seen = append(seen, "end") })
+	anim.Update(200 * time.Millisecond)
+	anim.Update(2 * time.Second)
+	anim.Update(1 * time.Second)
+	if strings.Join(seen, " ") != "start particles end" {
+		t.Fatalf("Markers were called as %v", seen)
+	}
+	anim.Update(-3 * time.Second)
+	anim.Update(300 * time.Millisecond)
+	if strings.Join(seen, " ") != "start particles end particles" {
+		t.Fatalf("Markers after rewinding were called as %v", seen)
+	}
+	anim.Reset()
+	anim.Update(100 * time.Millisecond)
+	if seen[len(seen)-1] != "start" {
+		t.Fatalf("Reset did not arm markers again")
+	}
+	if offset, ok := anim.Marker("particles"); !ok || offset != 350*time.Millisecond {
+		t.Fatalf("Marker offset was %v", offset)
+	}
+}
+
+// Tests that a reversed animation calls markers from its end.
+func TestBoundedAnimationReverseMarkers(t *testing.T) {
+	var (
+		anim = NewBoundedAnimation(1 * time.Second)
+		seen = []string{}
+	)
+	anim.SetDirection(Reverse)
+	anim.AddMarker("a", 200*time.Millisecond, func() { seen = append(seen, "a") })
+	var sub = anim.AddMarker("b", 700*time.Millisecond, func() { seen = append(seen, "b") })
+	anim.Update(500 * time.Millisecond)
+	sub.Unsubscribe()
+	anim.Seek(0, SeekSilent)
+	anim.Update(1 * time.Second)
+	if strings.Join(seen, " ") != "b a" {
+		t.Fatalf("Markers were called as %v", seen)
+	}
+}
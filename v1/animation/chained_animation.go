@@ -22,52 +22,260 @@ type ChainedAnimation struct {
 	animators []Animator
 	loop      bool
 	index     int
-	callback  AnimatorCallback
+	events    *Events
+	direction Direction
+	returning bool
+	scale     timeScale
 }
 
 func NewChainedAnimation(animators []Animator, loop bool) *ChainedAnimation {
-	return &ChainedAnimation{animators, loop, 0, nil}
+	return &ChainedAnimation{animators, loop, 0, &Events{}, Forward, false, newTimeScale(1)}
 }
 
 func (a *ChainedAnimation) SetCallback(callback AnimatorCallback) {
-	a.callback = callback
+	a.Events().setCallback(callback)
+}
+
+func (a *ChainedAnimation) Events() *Events {
+	return ensureEvents(&a.events)
 }
 
 func (a *ChainedAnimation) IsDone() bool {
 	var count = len(a.animators)
-	return !a.loop && count > 0 && a.animators[count-1].IsDone()
+	if a.direction == PingPong && !a.returning {
+		return false
+	}
+	return !a.loop && count > 0 && a.child(count-1).IsDone()
 }
 
+// Negative elapsed time moves the animation back towards its start.  It is
+// ignored if any child is not a Measurable Seeker, as there is then no way to
+// tell where the chain would be.
 func (a *ChainedAnimation) Update(elapsed time.Duration) time.Duration {
+	var remainder = a.advance(elapsed)
+	a.events.update(a.IsDone())
+	return remainder
+}
+
+func (a *ChainedAnimation) advance(elapsed time.Duration) time.Duration {
+	if elapsed < 0 {
+		if a.canRewind() {
+			a.Seek(max(a.Position()+elapsed, 0), SeekSilent)
+		}
+		return 0
+	}
+	if a.scale.frozen() {
+		if a.IsDone() {
+			return elapsed
+		}
+		return 0
+	}
+	elapsed = a.scale.toChild(elapsed)
 	var count = len(a.animators)
 	if count > a.index {
-		for elapsed > 0 && !a.animators[a.index].IsDone() {
-			elapsed = a.animators[a.index].Update(elapsed)
-			if a.animators[a.index].IsDone() {
+		for elapsed > 0 && !a.child(a.index).IsDone() {
+			elapsed = a.child(a.index).Update(elapsed)
+			if a.child(a.index).IsDone() {
 				if a.loop {
-					a.animators[a.index].Reset()
+					a.child(a.index).Reset()
 				}
 				a.index = (a.index + 1) % count
-				if !a.loop && a.index == 0 && a.callback != nil {
-					a.callback()
+				if a.index != 0 {
+					continue
+				}
+				if a.direction == PingPong && (a.loop || !a.returning) {
+					a.turn(!a.returning)
+					a.restart()
+					if a.returning {
+						continue
+					}
+				}
+				if !a.loop {
 					break
 				}
+				a.events.loop()
 			}
 		}
 	}
-	return elapsed
+	return a.scale.toParent(elapsed)
+}
+
+func (a *ChainedAnimation) canRewind() bool {
+	return canRewindAll(a.animators)
 }
 
 func (a *ChainedAnimation) Reset() {
 	a.index = 0
-	for _, animator := range a.animators {
-		animator.Reset()
+	if a.returning {
+		a.turn(false)
 	}
+	a.restart()
+	a.events.reset()
 }
 
 func (a *ChainedAnimation) Delete() {
+	a.events.delete()
 	for _, animator := range a.animators {
 		animator.Delete()
 	}
 	a.animators = []Animator{}
 }
+
+// Plays the children in reverse order when reversed.  The direction is also
+// applied to Directional children, so that each of them plays backwards too.
+func (a *ChainedAnimation) SetDirection(direction Direction) {
+	a.direction = direction
+	a.returning = false
+	a.orient()
+}
+
+func (a *ChainedAnimation) Direction() Direction {
+	return a.direction
+}
+
+// Runs the children at a multiple of the elapsed time.  Positions, lengths
+// and remainders are all reported in unscaled time.
+func (a *ChainedAnimation) SetTimeScale(scale float64) {
+	a.scale.set(scale)
+}
+
+func (a *ChainedAnimation) TimeScale() float64 {
+	return a.scale.scale
+}
+
+// Stops the children from advancing until Resume is called.
+func (a *ChainedAnimation) Pause() {
+	a.scale.paused = true
+}
+
+func (a *ChainedAnimation) Resume() {
+	a.scale.paused = false
+}
+
+func (a *ChainedAnimation) IsPaused() bool {
+	return a.scale.paused
+}
+
+func (a *ChainedAnimation) backwards() bool {
+	return a.direction == Reverse || a.returning
+}
+
+// Returns the child at a step in play order.
+func (a *ChainedAnimation) child(step int) Animator {
+	if a.backwards() {
+		return a.animators[len(a.animators)-1-step]
+	}
+	return a.animators[step]
+}
+
+// Points Directional children the way the current leg plays.
+func (a *ChainedAnimation) orient() {
+	var direction = Forward
+	if a.backwards() {
+		direction = Reverse
+	}
+	for _, animator := range a.animators {
+		SetAnimatorDirection(animator, direction)
+	}
+}
+
+// Switches between the outward and returning legs of a PingPong animation.
+// The children are pointed the new way but not moved.
+func (a *ChainedAnimation) turn(returning bool) {
+	a.returning = returning
+	a.orient()
+}
+
+// Resets the children to play the current leg.
+func (a *ChainedAnimation) restart() {
+	for _, animator := range a.animators {
+		animator.Reset()
+	}
+}
+
+// Seeks each child in turn.  A looping animation wraps around using the
+// length of one pass, so all of its children should be Measurable.
+func (a *ChainedAnimation) Seek(position time.Duration, mode SeekMode) time.Duration {
+	var (
+		leg       = a.legLength()
+		remaining = a.scale.toChild(max(position, 0))
+		current   = -1
+	)
+	if a.loop && leg > 0 {
+		remaining %= passLength(leg, a.direction)
+	}
+	if a.direction == PingPong && leg > 0 && remaining >= leg {
+		remaining -= leg
+		a.turn(true)
+	} else {
+		a.turn(false)
+	}
+	for step := range a.animators {
+		var animator = a.child(step)
+		if current >= 0 {
+			SeekAnimator(animator, 0, SeekSilent)
+			continue
+		}
+		var remainder = SeekAnimator(animator, remaining, mode)
+		if !animator.IsDone() {
+			current = step
+			continue
+		}
+		remaining = remainder
+		if a.loop {
+			// Looping chains rewind children as they finish.
+			SeekAnimator(animator, 0, SeekSilent)
+		}
+	}
+	if current < 0 {
+		current = 0
+	}
+	a.index = current
+	a.events.seek(a.IsDone(), mode)
+	if a.IsDone() {
+		return a.scale.toParent(remaining)
+	}
+	return 0
+}
+
+func (a *ChainedAnimation) Position() time.Duration {
+	var (
+		position time.Duration
+		last     = a.index
+	)
+	if a.returning {
+		position = a.legLength()
+	}
+	if a.IsDone() {
+		last = len(a.animators) - 1
+	}
+	for step := 0; step < last; step++ {
+		position += completedLength(a.child(step))
+	}
+	if last >= 0 && last < len(a.animators) {
+		position += PositionOf(a.child(last))
+	}
+	return a.scale.toParent(position)
+}
+
+// Returns how much time a finished child took up.
+func completedLength(animator Animator) time.Duration {
+	if m, ok := animator.(Measurable); ok {
+		return m.Length()
+	}
+	return PositionOf(animator)
+}
+
+// Returns the length of one pass through the children.
+func (a *ChainedAnimation) Length() time.Duration {
+	return a.scale.toParent(passLength(a.legLength(), a.direction))
+}
+
+// Returns the time the children take to play through once, in scaled time.
+func (a *ChainedAnimation) legLength() time.Duration {
+	var length time.Duration
+	for _, animator := range a.animators {
+		length += LengthOf(animator)
+	}
+	return length
+}
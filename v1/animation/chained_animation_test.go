@@ -22,9 +22,9 @@ import (
 func TestChainedAnimationSetCallback(t *testing.T) {
 	var (
 		done   = false
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = ChainedAnimation{[]Animator{child1, child2}, false, 0, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewChainedAnimation([]Animator{child1, child2}, false)
 		cb     = func() { done = true }
 	)
 	anim.SetCallback(cb)
@@ -40,9 +40,9 @@ func TestChainedAnimationSetCallback(t *testing.T) {
 
 func TestChainedAnimationIsDoneNoLoop(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = ChainedAnimation{[]Animator{child1, child2}, false, 0, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewChainedAnimation([]Animator{child1, child2}, false)
 	)
 	if anim.IsDone() {
 		t.Fatalf("ChainedAnimation.IsDone true too early")
@@ -63,9 +63,9 @@ func TestChainedAnimationIsDoneNoLoop(t *testing.T) {
 
 func TestChainedAnimationIsDoneLoop(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = ChainedAnimation{[]Animator{child1, child2}, true, 0, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewChainedAnimation([]Animator{child1, child2}, true)
 	)
 	if anim.IsDone() {
 		t.Fatalf("ChainedAnimation.IsDone should not be true for loops")
@@ -87,9 +87,9 @@ func TestChainedAnimationIsDoneLoop(t *testing.T) {
 
 func TestChainedAnimationUpdateNoLoop(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = ChainedAnimation{[]Animator{child1, child2}, false, 0, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewChainedAnimation([]Animator{child1, child2}, false)
 		resp   time.Duration
 	)
 	resp = anim.Update(500 * time.Millisecond)
@@ -133,9 +133,9 @@ func TestChainedAnimationUpdateNoLoop(t *testing.T) {
 
 func TestChainedAnimationUpdateLoop(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = ChainedAnimation{[]Animator{child1, child2}, true, 0, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewChainedAnimation([]Animator{child1, child2}, true)
 		resp   time.Duration
 	)
 	resp = anim.Update(500 * time.Millisecond)
@@ -189,9 +189,9 @@ func TestChainedAnimationUpdateLoop(t *testing.T) {
 
 func TestChainedAnimationReset(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{100 * time.Millisecond, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{200 * time.Millisecond, 2 * time.Second, nil}
-		anim   = ChainedAnimation{[]Animator{child1, child2}, true, 0, nil}
+		child1 = &BoundedAnimation{Elapsed: 100 * time.Millisecond, Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Elapsed: 200 * time.Millisecond, Duration: 2 * time.Second}
+		anim   = NewChainedAnimation([]Animator{child1, child2}, true)
 	)
 	anim.Reset()
 	if child1.Elapsed != 0 {
@@ -204,9 +204,9 @@ func TestChainedAnimationReset(t *testing.T) {
 
 func TestChainedAnimationDelete(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = ChainedAnimation{[]Animator{child1, child2}, true, 0, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewChainedAnimation([]Animator{child1, child2}, true)
 	)
 	anim.Delete()
 	anim.Update(100 * time.Millisecond)
@@ -214,3 +214,137 @@ func TestChainedAnimationDelete(t *testing.T) {
 		t.Fatalf("ChainedAnimation.Delete must remove references to children")
 	}
 }
+
+func TestChainedAnimationSeek(t *testing.T) {
+	var (
+		done   = 0
+		child  = 0
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(2 * time.Second)
+		anim   = NewChainedAnimation([]Animator{child1, child2}, false)
+		resp   time.Duration
+	)
+	anim.SetCallback(func() { done++ })
+	child1.SetCallback(func() { child++ })
+	anim.Seek(1500*time.Millisecond, SeekSilent)
+	if child1.Elapsed != 1500*time.Millisecond || child2.Elapsed != 500*time.Millisecond {
+		t.Fatalf("ChainedAnimation.Seek did not position children")
+	}
+	if anim.Position() != 1500*time.Millisecond || child != 0 {
+		t.Fatalf("ChainedAnimation.Seek did not seek silently")
+	}
+	anim.Update(600 * time.Millisecond)
+	if child2.Elapsed != 1100*time.Millisecond {
+		t.Fatalf("ChainedAnimation.Update did not continue from seek position")
+	}
+	anim.Seek(500*time.Millisecond, SeekReplay)
+	if child1.Elapsed != 500*time.Millisecond || child2.Elapsed != 0 || child != 0 {
+		t.Fatalf("ChainedAnimation.Seek backwards did not rewind children")
+	}
+	resp = anim.Seek(3200*time.Millisecond, SeekReplay)
+	if !anim.IsDone() || resp != 200*time.Millisecond {
+		t.Fatalf("ChainedAnimation.Seek did not finish, got remainder %v", resp)
+	}
+	if done != 1 || child != 1 {
+		t.Fatalf("ChainedAnimation.Seek did not replay crossed callbacks")
+	}
+	if anim.Position() != 3200*time.Millisecond {
+		t.Fatalf("ChainedAnimation.Position was %v", anim.Position())
+	}
+}
+
+func TestChainedAnimationSeekLoop(t *testing.T) {
+	var (
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(2 * time.Second)
+		anim   = NewChainedAnimation([]Animator{child1, child2}, true)
+	)
+	anim.Seek(4500*time.Millisecond, SeekSilent)
+	if child1.Elapsed != 0 || child2.Elapsed != 500*time.Millisecond {
+		t.Fatalf("Looping ChainedAnimation.Seek did not wrap")
+	}
+	if anim.Position() != 1500*time.Millisecond {
+		t.Fatalf("ChainedAnimation.Position was %v", anim.Position())
+	}
+}
+
+func TestChainedAnimationReverse(t *testing.T) {
+	var (
+		done   = false
+		dest   float32
+		child1 = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest)
+		child2 = NewBoundedAnimation(2 * time.Second)
+		anim   = NewChainedAnimation([]Animator{child1, child2}, false)
+		resp   time.Duration
+	)
+	anim.SetCallback(func() { done = true })
+	anim.SetDirection(Reverse)
+	anim.Reset()
+	anim.Update(2500 * time.Millisecond)
+	if !child2.IsDone() || child1.Elapsed != 500*time.Millisecond {
+		t.Fatalf("ChainedAnimation in reverse did not play the last child first")
+	}
+	if dest != 5 {
+		t.Fatalf("ChainedAnimation in reverse did not reverse its children, got %v", dest)
+	}
+	resp = anim.Update(600 * time.Millisecond)
+	if !anim.IsDone() || !done || dest != 0 || resp != 100*time.Millisecond {
+		t.Fatalf("ChainedAnimation in reverse did not finish at the start")
+	}
+}
+
+func TestChainedAnimationPingPong(t *testing.T) {
+	var (
+		dest   float32
+		child1 = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest)
+		child2 = NewBoundedAnimation(1 * time.Second)
+		anim   = NewChainedAnimation([]Animator{child1, child2}, false)
+	)
+	anim.SetDirection(PingPong)
+	if anim.Length() != 4*time.Second {
+		t.Fatalf("ChainedAnimation.Length was %v", anim.Length())
+	}
+	anim.Update(500 * time.Millisecond)
+	if dest != 5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(2 * time.Second)
+	if anim.IsDone() || anim.Position() != 2500*time.Millisecond {
+		t.Fatalf("ChainedAnimation did not turn around, at %v", anim.Position())
+	}
+	anim.Update(1250 * time.Millisecond)
+	if dest != 2.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(-3 * time.Second)
+	if dest != 7.5 || anim.Position() != 750*time.Millisecond {
+		t.Fatalf("Negative update did not rewind, got %v", dest)
+	}
+	if anim.Update(3300*time.Millisecond) != 50*time.Millisecond || !anim.IsDone() || dest != 0 {
+		t.Fatalf("ChainedAnimation did not finish after both legs")
+	}
+}
+
+func TestChainedAnimationRewindUnmeasurable(t *testing.T) {
+	var (
+		value  float32
+		spring = NewSpringAnimation(0, 10, &value)
+		child  = NewBoundedAnimation(1 * time.Second)
+		anim   = NewChainedAnimation([]Animator{spring, child}, false)
+		group  = NewGroupedAnimation([]Animator{NewSpringAnimation(0, 10, nil), NewBoundedAnimation(1 * time.Second)})
+	)
+	anim.Update(5 * time.Second)
+	var position = anim.Position()
+	anim.Update(-100 * time.Millisecond)
+	if anim.Position() != position || spring.Elapsed == 0 || value != 10 {
+		t.Fatalf("Negative update should be ignored with a SpringAnimation child, moved to %v", anim.Position())
+	}
+	group.Update(500 * time.Millisecond)
+	group.Update(-100 * time.Millisecond)
+	if group.Position() != 500*time.Millisecond {
+		t.Fatalf("GroupedAnimation rewound with a SpringAnimation child, got %v", group.Position())
+	}
+	if NewChainedAnimation([]Animator{anim}, false).canRewind() || !NewChainedAnimation([]Animator{child}, false).canRewind() {
+		t.Fatalf("Nested chains should only rewind when all of their children can")
+	}
+}
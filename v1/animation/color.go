@@ -0,0 +1,240 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+)
+
+// A non-premultiplied sRGB color with components in [0, 1].
+type RGBA struct {
+	R, G, B, A float32
+}
+
+// The space in which colors are blended.  Blending sRGB values directly
+// darkens midpoints; the other spaces avoid that in different ways.
+type ColorSpace int
+
+const (
+	ColorSpaceLinearRGB ColorSpace = iota
+	ColorSpaceHSV
+	ColorSpaceOKLab
+	ColorSpaceSRGB
+)
+
+func LerpRGBA(space ColorSpace) Lerp[RGBA] {
+	switch space {
+	case ColorSpaceHSV:
+		return LerpRGBAHSV
+	case ColorSpaceOKLab:
+		return LerpRGBAOKLab
+	case ColorSpaceSRGB:
+		return LerpRGBASRGB
+	}
+	return LerpRGBALinear
+}
+
+func NewRGBAAnimation(f ContinuousFunc, from, to RGBA, space ColorSpace, target *RGBA) *Tween[RGBA] {
+	return NewTween(f, from, to, LerpRGBA(space), target)
+}
+
+// Blends the stored sRGB components directly.
+func LerpRGBASRGB(a, b RGBA, t float64) RGBA {
+	return RGBA{
+		LerpFloat(a.R, b.R, t),
+		LerpFloat(a.G, b.G, t),
+		LerpFloat(a.B, b.B, t),
+		LerpFloat(a.A, b.A, t),
+	}
+}
+
+// Blends in linear light, which keeps brightness even across the fade.
+func LerpRGBALinear(a, b RGBA, t float64) RGBA {
+	var (
+		ar, ag, ab = a.linear()
+		br, bg, bb = b.linear()
+	)
+	return rgbaFromLinear(
+		LerpFloat(ar, br, t),
+		LerpFloat(ag, bg, t),
+		LerpFloat(ab, bb, t),
+		LerpFloat(float64(a.A), float64(b.A), t),
+	)
+}
+
+// Blends hue, saturation and value, taking the shorter way around the hue
+// circle.
+func LerpRGBAHSV(a, b RGBA, t float64) RGBA {
+	var (
+		ah, as, av = a.hsv()
+		bh, bs, bv = b.hsv()
+	)
+	// Grays have no hue, so borrow the other color's to avoid a sweep.
+	if as == 0 {
+		ah = bh
+	}
+	if bs == 0 {
+		bh = ah
+	}
+	var delta = bh - ah
+	if delta > 0.5 {
+		delta -= 1
+	} else if delta < -0.5 {
+		delta += 1
+	}
+	var h = ah + delta*t
+	h -= math.Floor(h)
+	return rgbaFromHSV(
+		h,
+		LerpFloat(as, bs, t),
+		LerpFloat(av, bv, t),
+		LerpFloat(float64(a.A), float64(b.A), t),
+	)
+}
+
+// Blends in the perceptually uniform OKLab space.
+func LerpRGBAOKLab(a, b RGBA, t float64) RGBA {
+	var (
+		al, aa, ab = a.oklab()
+		bl, ba, bb = b.oklab()
+	)
+	return rgbaFromOKLab(
+		LerpFloat(al, bl, t),
+		LerpFloat(aa, ba, t),
+		LerpFloat(ab, bb, t),
+		LerpFloat(float64(a.A), float64(b.A), t),
+	)
+}
+
+func srgbToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+func clampUnit(c float64) float32 {
+	return float32(math.Max(0, math.Min(1, c)))
+}
+
+func (c RGBA) linear() (r, g, b float64) {
+	return srgbToLinear(float64(c.R)), srgbToLinear(float64(c.G)), srgbToLinear(float64(c.B))
+}
+
+func rgbaFromLinear(r, g, b, a float64) RGBA {
+	return RGBA{
+		clampUnit(linearToSRGB(r)),
+		clampUnit(linearToSRGB(g)),
+		clampUnit(linearToSRGB(b)),
+		clampUnit(a),
+	}
+}
+
+// Returns hue, saturation and value, all in [0, 1].
+func (c RGBA) hsv() (h, s, v float64) {
+	var (
+		r     = float64(c.R)
+		g     = float64(c.G)
+		b     = float64(c.B)
+		max   = math.Max(r, math.Max(g, b))
+		min   = math.Min(r, math.Min(g, b))
+		delta = max - min
+	)
+	v = max
+	if max > 0 {
+		s = delta / max
+	}
+	if delta == 0 {
+		return
+	}
+	switch max {
+	case r:
+		h = (g - b) / delta
+	case g:
+		h = 2 + (b-r)/delta
+	default:
+		h = 4 + (r-g)/delta
+	}
+	h /= 6
+	h -= math.Floor(h)
+	return
+}
+
+func rgbaFromHSV(h, s, v, a float64) RGBA {
+	var (
+		sector = h * 6
+		i      = math.Floor(sector)
+		f      = sector - i
+		p      = v * (1 - s)
+		q      = v * (1 - s*f)
+		u      = v * (1 - s*(1-f))
+		r      float64
+		g      float64
+		b      float64
+	)
+	switch int(i) % 6 {
+	case 0:
+		r, g, b = v, u, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, u
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = u, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return RGBA{clampUnit(r), clampUnit(g), clampUnit(b), clampUnit(a)}
+}
+
+// Converts to OKLab, as defined by Björn Ottosson.
+func (c RGBA) oklab() (l, a, b float64) {
+	var (
+		lr, lg, lb = c.linear()
+		lc         = math.Cbrt(0.4122214708*lr + 0.5363325363*lg + 0.0514459929*lb)
+		mc         = math.Cbrt(0.2119034982*lr + 0.6806995451*lg + 0.1073969566*lb)
+		sc         = math.Cbrt(0.0883024619*lr + 0.2817188376*lg + 0.6299787005*lb)
+	)
+	l = 0.2104542553*lc + 0.7936177850*mc - 0.0040720468*sc
+	a = 1.9779984951*lc - 2.4285922050*mc + 0.4505937099*sc
+	b = 0.0259040371*lc + 0.7827717662*mc - 0.8086757660*sc
+	return
+}
+
+func rgbaFromOKLab(l, a, b, alpha float64) RGBA {
+	var (
+		lc = l + 0.3963377774*a + 0.2158037573*b
+		mc = l - 0.1055613458*a - 0.0638541728*b
+		sc = l - 0.0894841775*a - 1.2914855480*b
+		lo = lc * lc * lc
+		mo = mc * mc * mc
+		so = sc * sc * sc
+	)
+	return rgbaFromLinear(
+		+4.0767416621*lo-3.3077115913*mo+0.2309699292*so,
+		-1.2684380046*lo+2.6097574011*mo-0.3413193965*so,
+		-0.0041960863*lo-0.7034186147*mo+1.7076147010*so,
+		alpha,
+	)
+}
@@ -0,0 +1,96 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func rgbaNear(a, b RGBA) bool {
+	const tolerance = 1e-4
+	return math.Abs(float64(a.R-b.R)) < tolerance &&
+		math.Abs(float64(a.G-b.G)) < tolerance &&
+		math.Abs(float64(a.B-b.B)) < tolerance &&
+		math.Abs(float64(a.A-b.A)) < tolerance
+}
+
+// Tests that every color space returns the endpoints unchanged.
+func TestLerpRGBAEndpoints(t *testing.T) {
+	var (
+		from = RGBA{0.2, 0.4, 0.6, 1}
+		to   = RGBA{0.9, 0.1, 0.3, 0.5}
+	)
+	for _, space := range []ColorSpace{ColorSpaceLinearRGB, ColorSpaceHSV, ColorSpaceOKLab, ColorSpaceSRGB} {
+		var lerp = LerpRGBA(space)
+		if got := lerp(from, to, 0); !rgbaNear(got, from) {
+			t.Errorf("Color space %v did not start at %v, got %v", space, from, got)
+		}
+		if got := lerp(from, to, 1); !rgbaNear(got, to) {
+			t.Errorf("Color space %v did not end at %v, got %v", space, to, got)
+		}
+	}
+}
+
+// Tests that linear RGB blending keeps midpoints bright.
+func TestLerpRGBALinear(t *testing.T) {
+	var (
+		got      = LerpRGBALinear(RGBA{1, 0, 0, 1}, RGBA{0, 1, 0, 0}, 0.5)
+		expected = RGBA{0.735357, 0.735357, 0, 0.5}
+	)
+	if !rgbaNear(got, expected) {
+		t.Fatalf("Linear midpoint was %v, expected %v", got, expected)
+	}
+}
+
+// Tests that HSV blending takes the shorter way around the hue circle.
+func TestLerpRGBAHSV(t *testing.T) {
+	var got = LerpRGBAHSV(RGBA{1, 0, 0, 1}, RGBA{0, 0, 1, 1}, 0.5)
+	if !rgbaNear(got, RGBA{1, 0, 1, 1}) {
+		t.Fatalf("HSV midpoint of red and blue was %v, expected magenta", got)
+	}
+	got = LerpRGBAHSV(RGBA{0.5, 0.5, 0.5, 1}, RGBA{0, 0, 1, 1}, 0.5)
+	if got.R != got.G || got.B <= got.R {
+		t.Fatalf("HSV blend from gray swept through other hues, got %v", got)
+	}
+}
+
+// Tests that OKLab blending is perceptually even between black and white.
+func TestLerpRGBAOKLab(t *testing.T) {
+	var (
+		got      = LerpRGBAOKLab(RGBA{0, 0, 0, 1}, RGBA{1, 1, 1, 1}, 0.5)
+		expected = RGBA{0.388573, 0.388573, 0.388573, 1}
+	)
+	if !rgbaNear(got, expected) {
+		t.Fatalf("OKLab midpoint was %v, expected %v", got, expected)
+	}
+}
+
+// Tests that a color animation writes to its target.
+func TestRGBAAnimation(t *testing.T) {
+	var (
+		dest RGBA
+		anim = NewRGBAAnimation(LinearFunc(2*time.Second, 0, 1), RGBA{1, 0, 0, 1}, RGBA{0, 0, 1, 1}, ColorSpaceHSV, &dest)
+	)
+	anim.Update(1 * time.Second)
+	if !rgbaNear(dest, RGBA{1, 0, 1, 1}) {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(1 * time.Second)
+	if !rgbaNear(dest, RGBA{0, 0, 1, 1}) || !anim.IsDone() {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+}
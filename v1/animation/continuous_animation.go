@@ -23,41 +23,59 @@ import (
 type ContinuousFunc func(elapsed time.Duration) (float32, bool, time.Duration)
 
 type ContinuousAnimation struct {
-	Elapsed  time.Duration
-	function ContinuousFunc
-	target   *float32
-	callback AnimatorCallback
-	done     bool
+	Elapsed   time.Duration
+	function  ContinuousFunc
+	target    *float32
+	events    *Events
+	done      bool
+	direction Direction
+	length    time.Duration
+	markers   *markerSet
 }
 
 func NewContinuousAnimation(f ContinuousFunc, target *float32) *ContinuousAnimation {
 	return &ContinuousAnimation{
 		function: f,
 		target:   target,
+		events:   &Events{},
 		done:     false,
 	}
 }
 
+// Negative elapsed time moves the animation back towards its start.
 func (a *ContinuousAnimation) Update(elapsed time.Duration) time.Duration {
 	var (
 		remainder time.Duration
 		result    float32
 	)
-	a.Elapsed += elapsed
-	result, a.done, remainder = a.function(a.Elapsed)
+	a.Elapsed = max(a.Elapsed+elapsed, 0)
+	result, remainder = a.evaluate()
 	if a.target != nil {
 		*a.target = result
 	}
-	if a.IsDone() {
-		if a.callback != nil {
-			a.callback()
-		}
-	}
+	a.markers.play(a.Elapsed, a.markerLength(), a.direction)
+	a.events.update(a.done)
 	return remainder
 }
 
 func (a *ContinuousAnimation) SetCallback(callback AnimatorCallback) {
-	a.callback = callback
+	a.Events().setCallback(callback)
+}
+
+func (a *ContinuousAnimation) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+// Calls a callback when the animation reaches an offset from its start.
+// Markers are called once per pass, in order, even when one update jumps
+// past several of them, and are armed again by Reset.
+func (a *ContinuousAnimation) AddMarker(name string, offset time.Duration, callback AnimatorCallback) Subscription {
+	return ensureMarkers(&a.markers, a.Elapsed).add(name, offset, callback)
+}
+
+// Returns the offset of a marker.
+func (a *ContinuousAnimation) Marker(name string) (time.Duration, bool) {
+	return a.markers.find(name)
 }
 
 func (a *ContinuousAnimation) IsDone() bool {
@@ -67,9 +85,84 @@ func (a *ContinuousAnimation) IsDone() bool {
 func (a *ContinuousAnimation) Reset() {
 	a.done = false
 	a.Elapsed = 0
+	a.markers.reset()
+	a.events.reset()
+}
+
+func (a *ContinuousAnimation) Delete() {
+	a.events.delete()
+}
+
+func (a *ContinuousAnimation) Seek(position time.Duration, mode SeekMode) time.Duration {
+	var (
+		remainder time.Duration
+		result    float32
+	)
+	a.Elapsed = max(position, 0)
+	result, remainder = a.evaluate()
+	if a.target != nil {
+		*a.target = result
+	}
+	a.markers.seek(a.Elapsed, a.markerLength(), a.direction, mode)
+	a.events.seek(a.done, mode)
+	return remainder
 }
 
-func (a *ContinuousAnimation) Delete() {}
+func (a *ContinuousAnimation) Position() time.Duration {
+	return a.Elapsed
+}
+
+func (a *ContinuousAnimation) Length() time.Duration {
+	return passLength(ContinuousLength(a.function), a.direction)
+}
+
+// Functions which never finish can only play forwards.
+func (a *ContinuousAnimation) SetDirection(direction Direction) {
+	a.direction = direction
+	a.length = ContinuousLength(a.function)
+}
+
+func (a *ContinuousAnimation) Direction() Direction {
+	return a.direction
+}
+
+// Evaluates the function at the current position, updating done.
+func (a *ContinuousAnimation) evaluate() (result float32, remainder time.Duration) {
+	if a.direction == Forward || a.length <= 0 {
+		result, a.done, remainder = a.function(a.Elapsed)
+		return
+	}
+	var (
+		pass        = passLength(a.length, a.direction)
+		position, _ = playhead(a.Elapsed, a.length, a.direction, false)
+	)
+	result, _, _ = a.function(position)
+	a.done = a.Elapsed >= pass
+	remainder = a.Elapsed - pass
+	return
+}
+
+// Returns the length markers are placed within.  Forward animations may
+// have no end, so markers are placed along the time elapsed.
+func (a *ContinuousAnimation) markerLength() time.Duration {
+	if a.direction == Forward {
+		return 0
+	}
+	return a.length
+}
+
+// Far enough in the future that any finite function has finished.
+const continuousHorizon = time.Duration(1 << 62)
+
+// Returns how long a function runs, found from the remainder it reports once
+// it is done, or zero if it never finishes.
+func ContinuousLength(f ContinuousFunc) time.Duration {
+	var _, done, remainder = f(continuousHorizon)
+	if !done {
+		return 0
+	}
+	return continuousHorizon - remainder
+}
 
 func SineDecayFunc(duration time.Duration, amplitude, frequency, decay float32) ContinuousFunc {
 	var interval = float64(frequency * 2.0 * math.Pi)
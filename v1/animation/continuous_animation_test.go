@@ -77,3 +77,104 @@ func TestContinuousLinearAnimation(t *testing.T) {
 		t.Fatalf("Target value does not match expected, got %v", dest)
 	}
 }
+
+// Tests that seeking evaluates the function at the new position.
+func TestContinuousAnimationSeek(t *testing.T) {
+	var (
+		dest  float32 = 0.0
+		calls         = 0
+		anim          = NewContinuousAnimation(LinearFunc(5*time.Second, 10, 20), &dest)
+	)
+	anim.SetCallback(func() { calls++ })
+	anim.Seek(2500*time.Millisecond, SeekReplay)
+	if dest != 15 || anim.Position() != 2500*time.Millisecond {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if anim.Seek(6*time.Second, SeekSilent) != 1*time.Second || !anim.IsDone() || calls != 0 {
+		t.Fatalf("ContinuousAnimation.Seek did not finish silently")
+	}
+	anim.Seek(1*time.Second, SeekSilent)
+	if dest != 12 || anim.IsDone() {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Seek(5*time.Second, SeekReplay)
+	if calls != 1 {
+		t.Fatalf("ContinuousAnimation.Seek did not replay crossed callback")
+	}
+}
+
+// Tests that the length of a function is measured from its remainder.
+func TestContinuousLength(t *testing.T) {
+	if l := ContinuousLength(LinearFunc(5*time.Second, 10, 20)); l != 5*time.Second {
+		t.Fatalf("ContinuousLength returned %v", l)
+	}
+	if l := ContinuousLength(SineDecayFunc(3*time.Second, 5, 1, 1)); l != 3*time.Second {
+		t.Fatalf("ContinuousLength returned %v", l)
+	}
+}
+
+// Tests that a reversed linear animation runs from its end value to its start.
+func TestReverseContinuousAnimation(t *testing.T) {
+	var (
+		dest float32 = 0.0
+		done         = false
+		anim         = NewContinuousAnimation(LinearFunc(4*time.Second, 10, 20), &dest)
+	)
+	anim.SetCallback(func() { done = true })
+	anim.SetDirection(Reverse)
+	anim.Update(1 * time.Second)
+	if dest != 17.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(-2 * time.Second)
+	if dest != 20 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if anim.Update(5*time.Second) != 1*time.Second || dest != 10 || !done {
+		t.Fatalf("Reversed animation did not finish at its start value")
+	}
+}
+
+// Tests that a ping-pong animation returns to its start value.
+func TestPingPongContinuousAnimation(t *testing.T) {
+	var (
+		dest float32 = 0.0
+		anim         = NewContinuousAnimation(LinearFunc(4*time.Second, 10, 20), &dest)
+	)
+	anim.SetDirection(PingPong)
+	anim.Update(3 * time.Second)
+	if dest != 17.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(3 * time.Second)
+	if dest != 15 || anim.IsDone() {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(2 * time.Second)
+	if dest != 10 || !anim.IsDone() {
+		t.Fatalf("Ping-pong animation did not finish at its start value")
+	}
+}
+
+// Tests that a ping-pong animation crosses markers on the way out and back.
+func TestContinuousAnimationMarkers(t *testing.T) {
+	var (
+		dest  float32
+		anim  = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest)
+		seen  = []float32{}
+		count = 0
+	)
+	anim.AddMarker("spawn", 350*time.Millisecond, func() { seen = append(seen, dest) })
+	anim.Update(5 * time.Second)
+	if len(seen) != 1 || seen[0] != 10 {
+		t.Fatalf("Marker was called as %v", seen)
+	}
+	anim.SetDirection(PingPong)
+	anim.Reset()
+	anim.AddMarker("turn", 1*time.Second, func() { count++ })
+	anim.Update(1500 * time.Millisecond)
+	anim.Update(1 * time.Second)
+	if len(seen) != 3 || count != 1 {
+		t.Fatalf("Ping-pong markers were called %v and %v times", len(seen), count)
+	}
+}
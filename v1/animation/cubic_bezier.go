@@ -0,0 +1,239 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	bezierEpsilon       = 1e-7
+	bezierNewtonSteps   = 8
+	bezierBisectionStep = 64
+)
+
+// Builds an easing curve equivalent to the CSS cubic-bezier(x1, y1, x2, y2)
+// timing function.  The x coordinates must lie in [0, 1] for the curve to be
+// a function of time; they are clamped to that range.
+func CubicBezier(x1, y1, x2, y2 float64) Easing {
+	x1 = math.Max(0, math.Min(1, x1))
+	x2 = math.Max(0, math.Min(1, x2))
+	if x1 == y1 && x2 == y2 {
+		return EaseLinear
+	}
+	var (
+		cx = 3 * x1
+		bx = 3*(x2-x1) - cx
+		ax = 1 - cx - bx
+		cy = 3 * y1
+		by = 3*(y2-y1) - cy
+		ay = 1 - cy - by
+	)
+	sampleX := func(t float64) float64 {
+		return ((ax*t+bx)*t + cx) * t
+	}
+	sampleY := func(t float64) float64 {
+		return ((ay*t+by)*t + cy) * t
+	}
+	slopeX := func(t float64) float64 {
+		return (3*ax*t+2*bx)*t + cx
+	}
+	solve := func(x float64) float64 {
+		// Newton's method converges quickly for most curves.
+		var t = x
+		for i := 0; i < bezierNewtonSteps; i++ {
+			var err = sampleX(t) - x
+			if math.Abs(err) < bezierEpsilon {
+				return t
+			}
+			var slope = slopeX(t)
+			if math.Abs(slope) < 1e-6 {
+				break
+			}
+			t -= err / slope
+		}
+		// Fall back to bisection where the slope flattens out.
+		var lo, hi = 0.0, 1.0
+		t = x
+		for i := 0; i < bezierBisectionStep; i++ {
+			var sample = sampleX(t)
+			if math.Abs(sample-x) < bezierEpsilon {
+				break
+			}
+			if sample < x {
+				lo = t
+			} else {
+				hi = t
+			}
+			t = (lo + hi) / 2
+		}
+		return t
+	}
+	return func(x float64) float64 {
+		switch {
+		case x <= 0:
+			return 0
+		case x >= 1:
+			return 1
+		}
+		return sampleY(solve(x))
+	}
+}
+
+// Where the jumps of a Steps curve fall, matching the CSS step positions.
+type StepPosition int
+
+const (
+	JumpEnd StepPosition = iota
+	JumpStart
+	JumpNone
+	JumpBoth
+)
+
+// Builds an easing curve equivalent to the CSS steps(count, position)
+// timing function.
+func Steps(count int, position StepPosition) Easing {
+	var jumps = count
+	switch position {
+	case JumpNone:
+		jumps = count - 1
+	case JumpBoth:
+		jumps = count + 1
+	}
+	return func(t float64) float64 {
+		if jumps <= 0 {
+			return t
+		}
+		var step = math.Floor(t * float64(count))
+		if position == JumpStart || position == JumpBoth {
+			step++
+		}
+		if t >= 0 && step < 0 {
+			step = 0
+		}
+		if t <= 1 && step > float64(jumps) {
+			step = float64(jumps)
+		}
+		return step / float64(jumps)
+	}
+}
+
+var timingKeywords = map[string]Easing{
+	"linear":      EaseLinear,
+	"ease":        CubicBezier(0.25, 0.1, 0.25, 1),
+	"ease-in":     CubicBezier(0.42, 0, 1, 1),
+	"ease-out":    CubicBezier(0, 0, 0.58, 1),
+	"ease-in-out": CubicBezier(0.42, 0, 0.58, 1),
+	"step-start":  Steps(1, JumpStart),
+	"step-end":    Steps(1, JumpEnd),
+}
+
+var stepPositions = map[string]StepPosition{
+	"jump-start": JumpStart,
+	"start":      JumpStart,
+	"jump-end":   JumpEnd,
+	"end":        JumpEnd,
+	"jump-none":  JumpNone,
+	"jump-both":  JumpBoth,
+}
+
+// Parses a CSS timing function such as "ease-in-out",
+// "cubic-bezier(0.1, 0.7, 1.0, 0.1)" or "steps(4, jump-start)".
+func ParseTimingFunction(spec string) (Easing, error) {
+	var value = strings.ToLower(strings.TrimSpace(spec))
+	if ease, ok := timingKeywords[value]; ok {
+		return ease, nil
+	}
+	var open = strings.IndexByte(value, '(')
+	if open < 0 || !strings.HasSuffix(value, ")") {
+		return nil, fmt.Errorf("unknown timing function %q", spec)
+	}
+	var (
+		name = strings.TrimSpace(value[:open])
+		args = strings.Split(value[open+1:len(value)-1], ",")
+	)
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+	switch name {
+	case "cubic-bezier":
+		return parseCubicBezier(spec, args)
+	case "steps":
+		return parseSteps(spec, args)
+	}
+	return nil, fmt.Errorf("unknown timing function %q", spec)
+}
+
+func parseCubicBezier(spec string, args []string) (Easing, error) {
+	if len(args) != 4 {
+		return nil, fmt.Errorf("cubic-bezier takes 4 arguments, got %v in %q", len(args), spec)
+	}
+	var points [4]float64
+	for i, arg := range args {
+		var (
+			point float64
+			err   error
+		)
+		if point, err = strconv.ParseFloat(arg, 64); err != nil {
+			return nil, fmt.Errorf("invalid cubic-bezier argument %q in %q", arg, spec)
+		}
+		points[i] = point
+	}
+	if points[0] < 0 || points[0] > 1 || points[2] < 0 || points[2] > 1 {
+		return nil, fmt.Errorf("cubic-bezier x values must be in [0, 1] in %q", spec)
+	}
+	return CubicBezier(points[0], points[1], points[2], points[3]), nil
+}
+
+func parseSteps(spec string, args []string) (Easing, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("steps takes 1 or 2 arguments, got %v in %q", len(args), spec)
+	}
+	var (
+		count    int
+		position = JumpEnd
+		err      error
+		ok       bool
+	)
+	if count, err = strconv.Atoi(args[0]); err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid step count %q in %q", args[0], spec)
+	}
+	if len(args) == 2 {
+		if position, ok = stepPositions[args[1]]; !ok {
+			return nil, fmt.Errorf("invalid step position %q in %q", args[1], spec)
+		}
+	}
+	if position == JumpNone && count < 2 {
+		return nil, fmt.Errorf("steps with jump-none needs at least 2 steps in %q", spec)
+	}
+	return Steps(count, position), nil
+}
+
+// Interpolates between two values over a fixed duration using a CSS
+// timing function.
+func TimingFunc(duration time.Duration, from, to float32, spec string) (ContinuousFunc, error) {
+	var (
+		ease Easing
+		err  error
+	)
+	if ease, err = ParseTimingFunction(spec); err != nil {
+		return nil, err
+	}
+	return EasedFunc(duration, from, to, ease), nil
+}
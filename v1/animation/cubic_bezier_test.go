@@ -0,0 +1,120 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests bezier curves against values solved independently at high precision.
+func TestCubicBezierGoldenValues(t *testing.T) {
+	var (
+		points = [3]float64{0.25, 0.5, 0.75}
+		cases  = []struct {
+			spec   string
+			values [3]float64
+		}{
+			{"ease", [3]float64{0.408510591, 0.802403388, 0.960458978}},
+			{"ease-in-out", [3]float64{0.129161931, 0.5, 0.870838069}},
+			{"cubic-bezier(0.1, 0.7, 1.0, 0.1)", [3]float64{0.350421121, 0.417276783, 0.489875998}},
+			{"cubic-bezier(0, 1, 0, 1)", [3]float64{0.949330786, 0.991220003, 0.999235453}},
+		}
+	)
+	for _, c := range cases {
+		var ease, err = ParseTimingFunction(c.spec)
+		if err != nil {
+			t.Fatalf("ParseTimingFunction(%q) returned error %v", c.spec, err)
+		}
+		if ease(0) != 0 || ease(1) != 1 {
+			t.Errorf("%v does not start at 0 and end at 1", c.spec)
+		}
+		for i, point := range points {
+			if got := ease(point); math.Abs(got-c.values[i]) > 1e-5 {
+				t.Errorf("%v(%v) = %v, expected %v", c.spec, point, got, c.values[i])
+			}
+		}
+	}
+}
+
+// Tests that steps() jumps at the positions defined by CSS.
+func TestStepsPositions(t *testing.T) {
+	var (
+		points = [5]float64{0, 0.2, 0.5, 0.8, 1}
+		cases  = []struct {
+			spec   string
+			values [5]float64
+		}{
+			{"steps(4)", [5]float64{0, 0, 0.5, 0.75, 1}},
+			{"steps(4, jump-start)", [5]float64{0.25, 0.25, 0.75, 1, 1}},
+			{"steps(4, jump-none)", [5]float64{0, 0, 2.0 / 3, 1, 1}},
+			{"steps(4, jump-both)", [5]float64{0.2, 0.2, 0.6, 0.8, 1}},
+			{"step-start", [5]float64{1, 1, 1, 1, 1}},
+			{"step-end", [5]float64{0, 0, 0, 0, 1}},
+		}
+	)
+	for _, c := range cases {
+		var ease, err = ParseTimingFunction(c.spec)
+		if err != nil {
+			t.Fatalf("ParseTimingFunction(%q) returned error %v", c.spec, err)
+		}
+		for i, point := range points {
+			if got := ease(point); math.Abs(got-c.values[i]) > 1e-9 {
+				t.Errorf("%v(%v) = %v, expected %v", c.spec, point, got, c.values[i])
+			}
+		}
+	}
+}
+
+// Tests that malformed timing functions are rejected.
+func TestParseTimingFunctionErrors(t *testing.T) {
+	var specs = []string{
+		"",
+		"bouncy",
+		"cubic-bezier(0.1, 0.2, 0.3)",
+		"cubic-bezier(1.5, 0, 0.5, 1)",
+		"cubic-bezier(a, 0, 0.5, 1)",
+		"steps(0)",
+		"steps(3, sideways)",
+		"steps(1, jump-none)",
+		"steps(2",
+	}
+	for _, spec := range specs {
+		if _, err := ParseTimingFunction(spec); err == nil {
+			t.Errorf("ParseTimingFunction(%q) did not return an error", spec)
+		}
+	}
+}
+
+// Tests that a timing function drives a continuous animation.
+func TestContinuousTimingAnimation(t *testing.T) {
+	var (
+		dest   float32 = 0.0
+		f, err         = TimingFunc(4*time.Second, 0, 100, "steps(4)")
+	)
+	if err != nil {
+		t.Fatalf("TimingFunc returned error %v", err)
+	}
+	var anim = NewContinuousAnimation(f, &dest)
+	anim.Update(1500 * time.Millisecond)
+	if dest != 25 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(2500 * time.Millisecond)
+	if dest != 100 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+}
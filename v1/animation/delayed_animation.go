@@ -0,0 +1,128 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"time"
+)
+
+// Waits before starting an animator.  Time left over from the wait is passed
+// on to the animator.
+type DelayedAnimation struct {
+	animator Animator
+	delay    time.Duration
+	waited   time.Duration
+	events   *Events
+}
+
+func Delay(delay time.Duration, animator Animator) *DelayedAnimation {
+	return &DelayedAnimation{animator, max(delay, 0), 0, &Events{}}
+}
+
+// Returns the wrapped animator.
+func (a *DelayedAnimation) Animator() Animator {
+	return a.animator
+}
+
+func (a *DelayedAnimation) SetCallback(callback AnimatorCallback) {
+	a.Events().setCallback(callback)
+}
+
+// The start event is raised when the wait begins.
+func (a *DelayedAnimation) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+func (a *DelayedAnimation) IsDone() bool {
+	return a.waited >= a.delay && a.animator.IsDone()
+}
+
+// Negative elapsed time moves the animation back towards its start.  It is
+// ignored if the wrapped animator is not a Measurable Seeker.
+func (a *DelayedAnimation) Update(elapsed time.Duration) time.Duration {
+	var remainder = a.advance(elapsed)
+	a.events.update(a.IsDone())
+	return remainder
+}
+
+func (a *DelayedAnimation) advance(elapsed time.Duration) time.Duration {
+	if elapsed < 0 {
+		if a.canRewind() {
+			a.Seek(max(a.Position()+elapsed, 0), SeekSilent)
+		}
+		return 0
+	}
+	if a.waited < a.delay {
+		var wait = min(elapsed, a.delay-a.waited)
+		a.waited += wait
+		elapsed -= wait
+		if elapsed == 0 {
+			return 0
+		}
+	}
+	return a.animator.Update(elapsed)
+}
+
+func (a *DelayedAnimation) canRewind() bool {
+	return canRewind(a.animator)
+}
+
+func (a *DelayedAnimation) Reset() {
+	a.waited = 0
+	a.animator.Reset()
+	a.events.reset()
+}
+
+func (a *DelayedAnimation) Delete() {
+	a.events.delete()
+	a.animator.Delete()
+}
+
+func (a *DelayedAnimation) Seek(position time.Duration, mode SeekMode) time.Duration {
+	position = max(position, 0)
+	var remainder time.Duration
+	if position < a.delay {
+		a.waited = position
+		SeekAnimator(a.animator, 0, SeekSilent)
+	} else {
+		a.waited = a.delay
+		remainder = SeekAnimator(a.animator, position-a.delay, mode)
+	}
+	a.events.seek(a.IsDone(), mode)
+	return remainder
+}
+
+func (a *DelayedAnimation) Position() time.Duration {
+	if a.waited < a.delay {
+		return a.waited
+	}
+	return a.delay + PositionOf(a.animator)
+}
+
+func (a *DelayedAnimation) Length() time.Duration {
+	return a.delay + LengthOf(a.animator)
+}
+
+// The wait always comes first, whatever the direction.
+func (a *DelayedAnimation) SetDirection(direction Direction) {
+	SetAnimatorDirection(a.animator, direction)
+}
+
+func (a *DelayedAnimation) Direction() Direction {
+	if d, ok := a.animator.(Directional); ok {
+		return d.Direction()
+	}
+	return Forward
+}
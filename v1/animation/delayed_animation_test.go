@@ -0,0 +1,44 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelay(t *testing.T) {
+	var (
+		done  = false
+		child = NewBoundedAnimation(1 * time.Second)
+		anim  = Delay(500*time.Millisecond, child)
+	)
+	anim.SetCallback(func() { done = true })
+	anim.Update(400 * time.Millisecond)
+	if child.Elapsed != 0 || anim.Position() != 400*time.Millisecond {
+		t.Fatalf("Delay started its child too early")
+	}
+	anim.Update(400 * time.Millisecond)
+	if child.Elapsed != 300*time.Millisecond {
+		t.Fatalf("Delay did not pass on leftover time, got %v", child.Elapsed)
+	}
+	if anim.Update(1*time.Second) != 300*time.Millisecond || !done || !anim.IsDone() {
+		t.Fatalf("Delay did not finish")
+	}
+	anim.Seek(200*time.Millisecond, SeekSilent)
+	if anim.IsDone() || child.Elapsed != 0 || anim.Length() != 1500*time.Millisecond {
+		t.Fatalf("Delay did not seek back into the wait")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"time"
+)
+
+// The order in which an animation plays.  A PingPong animation plays
+// forwards and then backwards, finishing where it started.
+type Direction int
+
+const (
+	Forward Direction = iota
+	Reverse
+	PingPong
+)
+
+// Implemented by animators which can play backwards.  Changing direction does
+// not move the playhead, so call Reset to start over in the new direction.
+type Directional interface {
+	SetDirection(direction Direction)
+	Direction() Direction
+}
+
+// Sets the direction of an animator if it is Directional.
+func SetAnimatorDirection(animator Animator, direction Direction) {
+	if d, ok := animator.(Directional); ok {
+		d.SetDirection(direction)
+	}
+}
+
+// Returns how long one pass of an animation takes in a direction.
+func passLength(length time.Duration, direction Direction) time.Duration {
+	if direction == PingPong {
+		return 2 * length
+	}
+	return length
+}
+
+// Maps the time travelled since playback started to a position within an
+// animation, and reports whether the playhead is moving backwards there.
+func playhead(travel, length time.Duration, direction Direction, loop bool) (time.Duration, bool) {
+	var pass = passLength(length, direction)
+	if length <= 0 {
+		return 0, direction == Reverse
+	}
+	if loop {
+		travel %= pass
+		if travel < 0 {
+			travel += pass
+		}
+	} else {
+		travel = min(max(travel, 0), pass)
+	}
+	switch direction {
+	case Reverse:
+		return length - travel, true
+	case PingPong:
+		if travel > length {
+			return pass - travel, true
+		}
+	}
+	return travel, false
+}
@@ -0,0 +1,63 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests that the built-in animations can all change direction.
+func TestDirectional(t *testing.T) {
+	var anim Directional
+	anim = NewBoundedAnimation(1 * time.Second)
+	anim = NewChainedAnimation([]Animator{}, false)
+	anim = NewGroupedAnimation([]Animator{})
+	anim = NewFrameAnimation([]Frame{}, false, nil)
+	anim = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), nil)
+	anim = NewTween(LinearFunc(1*time.Second, 0, 1), 0, 10, LerpFloat[float64], nil)
+	anim = NewKeyframeTrack([]Keyframe{}, false, nil)
+	anim = NewTimeline()
+	anim = NewTimeScaled(NewBoundedAnimation(1*time.Second), 0.5)
+	anim = NewPausableAnimation(NewBoundedAnimation(1 * time.Second))
+	anim = Repeat(NewBoundedAnimation(1*time.Second), 2)
+	anim = Delay(1*time.Second, NewBoundedAnimation(1*time.Second))
+	t.Logf("Done checking interfaces for %v", anim)
+}
+
+func TestPlayhead(t *testing.T) {
+	var tests = []struct {
+		travel    time.Duration
+		direction Direction
+		loop      bool
+		position  time.Duration
+		backwards bool
+	}{
+		{250, Forward, false, 250, false},
+		{1500, Forward, false, 1000, false},
+		{250, Reverse, false, 750, true},
+		{1250, Reverse, true, 750, true},
+		{250, PingPong, false, 250, false},
+		{1250, PingPong, false, 750, true},
+		{2250, PingPong, true, 250, false},
+		{-250, Forward, true, 750, false},
+	}
+	for _, test := range tests {
+		var position, backwards = playhead(test.travel, 1000, test.direction, test.loop)
+		if position != test.position || backwards != test.backwards {
+			t.Fatalf("playhead(%v, %v) was %v, %v", test.travel, test.direction, position, backwards)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"time"
+)
+
+// Maps linear progress in [0, 1] to eased progress.  Eased progress starts
+// at 0 and ends at 1 but may leave that range in between (see EaseInBack).
+type Easing func(t float64) float64
+
+// Interpolates between two values over a fixed duration using an easing curve.
+func EasedFunc(duration time.Duration, from, to float32, ease Easing) ContinuousFunc {
+	return func(elapsed time.Duration) (value float32, done bool, remainder time.Duration) {
+		var (
+			denom = float64(duration)
+			numer = math.Min(float64(elapsed), denom)
+			pct   = float32(ease(numer / denom))
+		)
+		value = pct*(to-from) + from
+		done = elapsed >= duration
+		remainder = elapsed - duration
+		return
+	}
+}
+
+// Applies an easing curve to the progress of an existing function which
+// runs for the given duration.  Completion and remainder are still reported
+// against real elapsed time.
+func EaseFunc(f ContinuousFunc, duration time.Duration, ease Easing) ContinuousFunc {
+	return func(elapsed time.Duration) (value float32, done bool, remainder time.Duration) {
+		var (
+			denom = float64(duration)
+			numer = math.Min(float64(elapsed), denom)
+			eased = time.Duration(ease(numer/denom) * denom)
+		)
+		value, _, _ = f(eased)
+		done = elapsed >= duration
+		remainder = elapsed - duration
+		return
+	}
+}
+
+// Builds an ease-out curve from an ease-in curve.
+func EaseOut(in Easing) Easing {
+	return func(t float64) float64 {
+		return 1 - in(1-t)
+	}
+}
+
+// Builds an ease-in-out curve from an ease-in curve.
+func EaseInOut(in Easing) Easing {
+	return func(t float64) float64 {
+		if t < 0.5 {
+			return in(2*t) / 2
+		}
+		return 1 - in(2-2*t)/2
+	}
+}
+
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+func EaseOutQuad(t float64) float64 {
+	return EaseOut(EaseInQuad)(t)
+}
+
+func EaseInOutQuad(t float64) float64 {
+	return EaseInOut(EaseInQuad)(t)
+}
+
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+func EaseOutCubic(t float64) float64 {
+	return EaseOut(EaseInCubic)(t)
+}
+
+func EaseInOutCubic(t float64) float64 {
+	return EaseInOut(EaseInCubic)(t)
+}
+
+func EaseInQuart(t float64) float64 {
+	return t * t * t * t
+}
+
+func EaseOutQuart(t float64) float64 {
+	return EaseOut(EaseInQuart)(t)
+}
+
+func EaseInOutQuart(t float64) float64 {
+	return EaseInOut(EaseInQuart)(t)
+}
+
+func EaseInQuint(t float64) float64 {
+	return t * t * t * t * t
+}
+
+func EaseOutQuint(t float64) float64 {
+	return EaseOut(EaseInQuint)(t)
+}
+
+func EaseInOutQuint(t float64) float64 {
+	return EaseInOut(EaseInQuint)(t)
+}
+
+func EaseInSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+func EaseOutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+func EaseInOutSine(t float64) float64 {
+	return -(math.Cos(t*math.Pi) - 1) / 2
+}
+
+func EaseInExpo(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*t-10)
+}
+
+func EaseOutExpo(t float64) float64 {
+	return EaseOut(EaseInExpo)(t)
+}
+
+func EaseInOutExpo(t float64) float64 {
+	return EaseInOut(EaseInExpo)(t)
+}
+
+func EaseInCirc(t float64) float64 {
+	return 1 - math.Sqrt(1-t*t)
+}
+
+func EaseOutCirc(t float64) float64 {
+	return EaseOut(EaseInCirc)(t)
+}
+
+func EaseInOutCirc(t float64) float64 {
+	return EaseInOut(EaseInCirc)(t)
+}
+
+// Overshoot used by the Back family, giving a 10% dip below zero.
+const BackOvershoot = 1.70158
+
+// Builds a Back ease-in curve with a custom overshoot.
+func BackEasing(overshoot float64) Easing {
+	return func(t float64) float64 {
+		return (overshoot+1)*t*t*t - overshoot*t*t
+	}
+}
+
+func EaseInBack(t float64) float64 {
+	return BackEasing(BackOvershoot)(t)
+}
+
+func EaseOutBack(t float64) float64 {
+	return EaseOut(EaseInBack)(t)
+}
+
+// The in-out variant scales the overshoot so that each half dips by 10%.
+func EaseInOutBack(t float64) float64 {
+	return EaseInOut(BackEasing(BackOvershoot * 1.525))(t)
+}
+
+func EaseInElastic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((10*t-10.75)*2*math.Pi/3)
+}
+
+func EaseOutElastic(t float64) float64 {
+	return EaseOut(EaseInElastic)(t)
+}
+
+// The in-out variant uses a longer period so each half completes its swing.
+func EaseInOutElastic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case t < 0.5:
+		return -math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*2*math.Pi/4.5) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*2*math.Pi/4.5)/2 + 1
+}
+
+func EaseOutBounce(t float64) float64 {
+	const (
+		n = 7.5625
+		d = 2.75
+	)
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	}
+	t -= 2.625 / d
+	return n*t*t + 0.984375
+}
+
+func EaseInBounce(t float64) float64 {
+	return EaseOut(EaseOutBounce)(t)
+}
+
+func EaseInOutBounce(t float64) float64 {
+	return EaseInOut(EaseInBounce)(t)
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests every easing curve against golden values at fixed sample points.
+func TestEasingGoldenValues(t *testing.T) {
+	var (
+		points = [5]float64{0, 0.25, 0.5, 0.75, 1}
+		cases  = []struct {
+			name   string
+			ease   Easing
+			values [5]float64
+		}{
+			{"EaseLinear", EaseLinear, [5]float64{0.0, 0.25, 0.5, 0.75, 1.0}},
+			{"EaseInQuad", EaseInQuad, [5]float64{0.0, 0.0625, 0.25, 0.5625, 1.0}},
+			{"EaseOutQuad", EaseOutQuad, [5]float64{0.0, 0.4375, 0.75, 0.9375, 1.0}},
+			{"EaseInOutQuad", EaseInOutQuad, [5]float64{0.0, 0.125, 0.5, 0.875, 1.0}},
+			{"EaseInCubic", EaseInCubic, [5]float64{0.0, 0.015625, 0.125, 0.421875, 1.0}},
+			{"EaseOutCubic", EaseOutCubic, [5]float64{0.0, 0.578125, 0.875, 0.984375, 1.0}},
+			{"EaseInOutCubic", EaseInOutCubic, [5]float64{0.0, 0.0625, 0.5, 0.9375, 1.0}},
+			{"EaseInQuart", EaseInQuart, [5]float64{0.0, 0.00390625, 0.0625, 0.31640625, 1.0}},
+			{"EaseOutQuart", EaseOutQuart, [5]float64{0.0, 0.68359375, 0.9375, 0.99609375, 1.0}},
+			{"EaseInOutQuart", EaseInOutQuart, [5]float64{0.0, 0.03125, 0.5, 0.96875, 1.0}},
+			{"EaseInQuint", EaseInQuint, [5]float64{0.0, 0.0009765625, 0.03125, 0.2373046875, 1.0}},
+			{"EaseOutQuint", EaseOutQuint, [5]float64{0.0, 0.7626953125, 0.96875, 0.9990234375, 1.0}},
+			{"EaseInOutQuint", EaseInOutQuint, [5]float64{0.0, 0.015625, 0.5, 0.984375, 1.0}},
+			{"EaseInSine", EaseInSine, [5]float64{0.0, 0.0761204675, 0.2928932188, 0.6173165676, 1.0}},
+			{"EaseOutSine", EaseOutSine, [5]float64{0.0, 0.3826834324, 0.7071067812, 0.9238795325, 1.0}},
+			{"EaseInOutSine", EaseInOutSine, [5]float64{0.0, 0.1464466094, 0.5, 0.8535533906, 1.0}},
+			{"EaseInExpo", EaseInExpo, [5]float64{0.0, 0.0055242717, 0.03125, 0.1767766953, 1.0}},
+			{"EaseOutExpo", EaseOutExpo, [5]float64{0.0, 0.8232233047, 0.96875, 0.9944757283, 1.0}},
+			{"EaseInOutExpo", EaseInOutExpo, [5]float64{0.0, 0.015625, 0.5, 0.984375, 1.0}},
+			{"EaseInCirc", EaseInCirc, [5]float64{0.0, 0.0317541634, 0.1339745962, 0.3385621722, 1.0}},
+			{"EaseOutCirc", EaseOutCirc, [5]float64{0.0, 0.6614378278, 0.8660254038, 0.9682458366, 1.0}},
+			{"EaseInOutCirc", EaseInOutCirc, [5]float64{0.0, 0.0669872981, 0.5, 0.9330127019, 1.0}},
+			{"EaseInBack", EaseInBack, [5]float64{0.0, -0.0641365625, -0.0876975, 0.1825903125, 1.0}},
+			{"EaseOutBack", EaseOutBack, [5]float64{0.0, 0.8174096875, 1.0876975, 1.0641365625, 1.0}},
+			{"EaseInOutBack", EaseInOutBack, [5]float64{0.0, -0.0996818437, 0.5, 1.0996818437, 1.0}},
+			{"EaseInElastic", EaseInElastic, [5]float64{0.0, -0.0055242717, -0.015625, 0.0883883476, 1.0}},
+			{"EaseOutElastic", EaseOutElastic, [5]float64{0.0, 0.9116116524, 1.015625, 1.0055242717, 1.0}},
+			{"EaseInOutElastic", EaseInOutElastic, [5]float64{0.0, 0.0119694444, 0.5, 0.9880305556, 1.0}},
+			{"EaseInBounce", EaseInBounce, [5]float64{0.0, 0.02734375, 0.234375, 0.52734375, 1.0}},
+			{"EaseOutBounce", EaseOutBounce, [5]float64{0.0, 0.47265625, 0.765625, 0.97265625, 1.0}},
+			{"EaseInOutBounce", EaseInOutBounce, [5]float64{0.0, 0.1171875, 0.5, 0.8828125, 1.0}},
+		}
+	)
+	for _, c := range cases {
+		for i, point := range points {
+			if got := c.ease(point); math.Abs(got-c.values[i]) > 1e-9 {
+				t.Errorf("%v(%v) = %v, expected %v", c.name, point, got, c.values[i])
+			}
+		}
+	}
+}
+
+// Tests that an eased function drives a continuous animation.
+func TestContinuousEasedAnimation(t *testing.T) {
+	var (
+		dest float32 = 0.0
+		anim         = NewContinuousAnimation(EasedFunc(4*time.Second, 10, 20, EaseInQuad), &dest)
+	)
+	anim.Update(1 * time.Second)
+	if dest != 10.625 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(1 * time.Second)
+	if dest != 12.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(2 * time.Second)
+	if dest != 20 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if !anim.IsDone() {
+		t.Fatalf("Eased animation not marked done when finished")
+	}
+}
+
+// Tests that EaseFunc remaps the progress of an existing function.
+func TestEaseFunc(t *testing.T) {
+	var (
+		f                 = EaseFunc(LinearFunc(4*time.Second, 0, 100), 4*time.Second, EaseOutQuad)
+		value     float32 = 0
+		done              = false
+		remainder time.Duration
+	)
+	value, done, _ = f(2 * time.Second)
+	if value != 75 || done {
+		t.Fatalf("EaseFunc produced unexpected value %v", value)
+	}
+	value, done, remainder = f(5 * time.Second)
+	if value != 100 || !done || remainder != 1*time.Second {
+		t.Fatalf("EaseFunc produced unexpected result %v %v %v", value, done, remainder)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"time"
+)
+
+// A point in the life of an animator which callbacks can subscribe to.
+type Event int
+
+const (
+	// The first update after the animator was created or reset.
+	EventStart Event = iota
+	// Every update.
+	EventUpdate
+	// Each time a looping or repeating animator starts over.
+	EventLoop
+	// Each time the animator becomes done.  Updates while already done do
+	// not raise it again.
+	EventComplete
+	EventReset
+	EventDelete
+	eventCount
+)
+
+// Implemented by animators which raise lifecycle events.
+type Observable interface {
+	Events() *Events
+}
+
+// Returns the events of an animator, or nil if it is not Observable.
+func EventsOf(animator Animator) *Events {
+	if o, ok := animator.(Observable); ok {
+		return o.Events()
+	}
+	return nil
+}
+
+type listener[F any] struct {
+	callback F
+	removed  bool
+}
+
+// The subscribers to the events of one animator.  Callbacks for an event are
+// called in the order they subscribed, and may subscribe or unsubscribe
+// while being called.
+type Events struct {
+	listeners [eventCount][]*listener[AnimatorCallback]
+	callback  AnimatorCallback
+	started   bool
+	completed bool
+}
+
+// Returned when subscribing, to unsubscribe later.
+type Subscription struct {
+	cancel func()
+}
+
+// Stops the callback from being called.  Unsubscribing more than once has no
+// further effect.
+func (s Subscription) Unsubscribe() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// Adds a callback to a list of listeners.
+func subscribe[F any](list *[]*listener[F], callback F) Subscription {
+	var l = &listener[F]{callback: callback}
+	*list = append(*list, l)
+	return Subscription{func() {
+		if l.removed {
+			return
+		}
+		l.removed = true
+		// Copied rather than changed in place, since the old list may be in
+		// the middle of being called.
+		var kept = make([]*listener[F], 0, len(*list))
+		for _, other := range *list {
+			if other != l {
+				kept = append(kept, other)
+			}
+		}
+		*list = kept
+	}}
+}
+
+func (e *Events) On(event Event, callback AnimatorCallback) Subscription {
+	return subscribe(&e.listeners[event], callback)
+}
+
+func (e *Events) OnStart(callback AnimatorCallback) Subscription {
+	return e.On(EventStart, callback)
+}
+
+func (e *Events) OnUpdate(callback AnimatorCallback) Subscription {
+	return e.On(EventUpdate, callback)
+}
+
+func (e *Events) OnLoop(callback AnimatorCallback) Subscription {
+	return e.On(EventLoop, callback)
+}
+
+func (e *Events) OnComplete(callback AnimatorCallback) Subscription {
+	return e.On(EventComplete, callback)
+}
+
+func (e *Events) OnReset(callback AnimatorCallback) Subscription {
+	return e.On(EventReset, callback)
+}
+
+func (e *Events) OnDelete(callback AnimatorCallback) Subscription {
+	return e.On(EventDelete, callback)
+}
+
+func (e *Events) fire(event Event) {
+	for _, l := range e.listeners[event] {
+		if !l.removed {
+			l.callback()
+		}
+	}
+}
+
+// Returns the events, creating them for animators built without a
+// constructor.
+func ensureEvents(events **Events) *Events {
+	if *events == nil {
+		*events = &Events{}
+	}
+	return *events
+}
+
+// The methods below are called by animators, and do nothing on nil Events.
+
+// Replaces the callback set through SetCallback, which is called on
+// completion before any subscribers.
+func (e *Events) setCallback(callback AnimatorCallback) {
+	e.callback = callback
+}
+
+// Raises the events for an update which left the animator done or not.
+func (e *Events) update(done bool) {
+	if e == nil {
+		return
+	}
+	e.start()
+	e.fire(EventUpdate)
+	e.finish(done)
+}
+
+// Tracks completion after a seek, which only raises events on replay.
+func (e *Events) seek(done bool, mode SeekMode) {
+	if e == nil {
+		return
+	}
+	if mode == SeekSilent {
+		e.completed = done
+		return
+	}
+	e.start()
+	e.finish(done)
+}
+
+func (e *Events) start() {
+	if !e.started {
+		e.started = true
+		e.fire(EventStart)
+	}
+}
+
+func (e *Events) finish(done bool) {
+	if !done {
+		// Moving back from the end allows the animator to complete again.
+		e.completed = false
+		return
+	}
+	if e.completed {
+		return
+	}
+	e.completed = true
+	if e.callback != nil {
+		e.callback()
+	}
+	e.fire(EventComplete)
+}
+
+// Raises a loop event, which comes before the update event.
+func (e *Events) loop() {
+	if e == nil {
+		return
+	}
+	e.start()
+	e.fire(EventLoop)
+}
+
+// Raises a loop event for each time playback wrapped around between two
+// points in time, given how long each pass takes.
+func (e *Events) loops(previous, travel, pass time.Duration) {
+	if pass <= 0 {
+		return
+	}
+	for i := previous / pass; i < travel/pass; i++ {
+		e.loop()
+	}
+}
+
+func (e *Events) reset() {
+	if e == nil {
+		return
+	}
+	e.started = false
+	e.completed = false
+	e.fire(EventReset)
+}
+
+func (e *Events) delete() {
+	if e != nil {
+		e.fire(EventDelete)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+// Returns a finite animation of each built-in type, all one second long.
+func finiteAnimations() map[string]Animator {
+	var frames = []Frame{MsFrame(500, 0), MsFrame(500, 1)}
+	var keys = []Keyframe{
+		{0, 0, InterpolateLinear, nil, 0, 0},
+		{1 * time.Second, 10, InterpolateLinear, nil, 0, 0},
+	}
+	var timeline = NewTimeline()
+	timeline.Add(NewBoundedAnimation(1*time.Second), "")
+	return map[string]Animator{
+		"BoundedAnimation":    NewBoundedAnimation(1 * time.Second),
+		"ChainedAnimation":    NewChainedAnimation([]Animator{NewBoundedAnimation(1 * time.Second)}, false),
+		"ContinuousAnimation": NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), nil),
+		"DelayedAnimation":    Delay(500*time.Millisecond, NewBoundedAnimation(500*time.Millisecond)),
+		"FrameAnimation":      NewFrameAnimation(frames, false, nil),
+		"GroupedAnimation":    NewGroupedAnimation([]Animator{NewBoundedAnimation(1 * time.Second)}),
+		"KeyframeTrack":       NewKeyframeTrack(keys, false, nil),
+		"PausableAnimation":   NewPausableAnimation(NewBoundedAnimation(1 * time.Second)),
+		"RepeatAnimation":     Repeat(NewBoundedAnimation(500*time.Millisecond), 2),
+		"SpringAnimation":     NewSpringAnimation(0, 10, nil),
+		"TimeScaled":          NewTimeScaled(NewBoundedAnimation(500*time.Millisecond), 0.5),
+		"Timeline":            timeline,
+		"Tween":               NewTween(LinearFunc(1*time.Second, 0, 1), 0, 10, LerpFloat[float64], nil),
+	}
+}
+
+// Tests that each built-in animation completes exactly once, however many
+// times it is updated afterwards.
+func TestEventsCompleteOnce(t *testing.T) {
+	for name, anim := range finiteAnimations() {
+		var (
+			events    = EventsOf(anim)
+			completed = 0
+			called    = 0
+		)
+		if events == nil {
+			t.Fatalf("%v is not Observable", name)
+		}
+		events.OnComplete(func() { completed++ })
+		anim.SetCallback(func() { called++ })
+		for i := 0; i < 10; i++ {
+			anim.Update(1 * time.Second)
+		}
+		if !anim.IsDone() || completed != 1 || called != 1 {
+			t.Fatalf("%v completed %v times, called back %v times", name, completed, called)
+		}
+		anim.Reset()
+		for i := 0; i < 10; i++ {
+			anim.Update(1 * time.Second)
+		}
+		if completed != 2 || called != 2 {
+			t.Fatalf("%v did not complete again after Reset", name)
+		}
+	}
+}
+
+func TestEventsLifecycle(t *testing.T) {
+	var (
+		anim   = NewBoundedAnimation(1 * time.Second)
+		events = anim.Events()
+		seen   = []string{}
+		record = func(name string) AnimatorCallback {
+			return func() { seen = append(seen, name) }
+		}
+	)
+	events.OnStart(record("start"))
+	events.OnUpdate(record("update"))
+	events.OnComplete(record("complete"))
+	events.OnReset(record("reset"))
+	events.OnDelete(record("delete"))
+	anim.Update(500 * time.Millisecond)
+	anim.Update(500 * time.Millisecond)
+	anim.Update(-200 * time.Millisecond)
+	anim.Update(500 * time.Millisecond)
+	anim.Reset()
+	anim.Update(100 * time.Millisecond)
+	anim.Delete()
+	var expected = []string{
+		"start", "update", "update", "complete", "update", "update", "complete",
+		"reset", "start", "update", "delete",
+	}
+	if len(seen) != len(expected) {
+		t.Fatalf("Events were %v, expected %v", seen, expected)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("Events were %v, expected %v", seen, expected)
+		}
+	}
+}
+
+func TestEventsUnsubscribe(t *testing.T) {
+	var (
+		anim   = NewBoundedAnimation(1 * time.Second)
+		first  = 0
+		second = 0
+		sub    Subscription
+	)
+	sub = anim.Events().OnUpdate(func() {
+		first++
+		sub.Unsubscribe()
+	})
+	anim.Events().OnUpdate(func() { second++ })
+	anim.Update(100 * time.Millisecond)
+	anim.Update(100 * time.Millisecond)
+	sub.Unsubscribe()
+	if first != 1 || second != 2 {
+		t.Fatalf("Unsubscribed callback was called, got %v and %v", first, second)
+	}
+}
+
+func TestEventsLoop(t *testing.T) {
+	var (
+		frames = []Frame{MsFrame(100, 0), MsFrame(100, 1)}
+		anims  = map[string]Animator{
+			"FrameAnimation":   NewFrameAnimation(frames, true, nil),
+			"ChainedAnimation": NewChainedAnimation([]Animator{NewBoundedAnimation(200 * time.Millisecond)}, true),
+			"RepeatAnimation":  Repeat(NewBoundedAnimation(200*time.Millisecond), RepeatForever),
+		}
+	)
+	for name, anim := range anims {
+		var loops = 0
+		EventsOf(anim).OnLoop(func() { loops++ })
+		anim.Update(150 * time.Millisecond)
+		anim.Update(100 * time.Millisecond)
+		anim.Update(350 * time.Millisecond)
+		if loops != 3 {
+			t.Fatalf("%v looped %v times", name, loops)
+		}
+	}
+}
+
+// Tests that a silent seek past the end does not complete on the next update.
+func TestEventsSeek(t *testing.T) {
+	var (
+		anim      = NewBoundedAnimation(1 * time.Second)
+		completed = 0
+	)
+	anim.Events().OnComplete(func() { completed++ })
+	anim.Seek(2*time.Second, SeekSilent)
+	anim.Update(100 * time.Millisecond)
+	if completed != 0 {
+		t.Fatalf("Silent seek completed later")
+	}
+	anim.Seek(0, SeekSilent)
+	anim.Seek(2*time.Second, SeekReplay)
+	if completed != 1 {
+		t.Fatalf("Replayed seek did not complete")
+	}
+}
+
+func TestEventsResetOnce(t *testing.T) {
+	var (
+		resets = 0
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(1 * time.Second)
+		anim   = NewChainedAnimation([]Animator{child1, child2}, false)
+	)
+	child1.Events().OnReset(func() { resets++ })
+	anim.SetDirection(PingPong)
+	anim.Update(2500 * time.Millisecond)
+	if resets != 1 {
+		t.Fatalf("Turning around should reset each child once, got %v", resets)
+	}
+	anim.Reset()
+	if resets != 2 {
+		t.Fatalf("Reset on the returning leg should reset each child once, got %v", resets)
+	}
+}
+
+func TestEventsSeekSilentDoesNotReset(t *testing.T) {
+	var (
+		resets   = 0
+		children = []*BoundedAnimation{}
+		child    = func() Animator {
+			var c = NewBoundedAnimation(1 * time.Second)
+			c.Events().OnReset(func() { resets++ })
+			children = append(children, c)
+			return c
+		}
+		chained  = NewChainedAnimation([]Animator{child(), child()}, true)
+		timeline = NewTimeline()
+		repeat   = RepeatDelay(child(), 3, 500*time.Millisecond)
+		delayed  = Delay(1*time.Second, child())
+	)
+	chained.SetDirection(PingPong)
+	timeline.Add(child(), "")
+	timeline.Add(child(), "")
+	timeline.SetDirection(PingPong)
+	for _, anim := range []Animator{chained, timeline, repeat, delayed} {
+		var seeker = anim.(Seeker)
+		seeker.Seek(2500*time.Millisecond, SeekSilent)
+		seeker.Seek(1200*time.Millisecond, SeekSilent)
+		anim.Update(-100 * time.Millisecond)
+		seeker.Seek(500*time.Millisecond, SeekSilent)
+	}
+	if resets != 0 {
+		t.Fatalf("Silent seeks should not reset children, got %v resets", resets)
+	}
+	if children[0].Elapsed != 500*time.Millisecond || children[5].Elapsed != 0 {
+		t.Fatalf("Seeks did not move the children")
+	}
+}
@@ -23,75 +23,259 @@ type Frame struct {
 	Index    int
 }
 
+// Raised when a frame is shown, for example to play a footstep sound.
+type FrameEvent struct {
+	Name    string
+	Payload any
+}
+
+type FrameEventCallback func(frame Frame, event FrameEvent)
+
 func MsFrame(milliseconds, index int) Frame {
 	return Frame{time.Duration(milliseconds) * time.Millisecond, index}
 }
 
 type FrameAnimation struct {
 	Elapsed   time.Duration
-	remainder time.Duration
 	Duration  time.Duration
-	callback  AnimatorCallback
+	events    *Events
 	sequence  []Frame
 	current   int
 	loop      bool
 	target    *int
+	output    func(index int)
+	direction Direction
+	listeners []*listener[FrameEventCallback]
+	marks     map[int][]FrameEvent
+	fired     time.Duration
 }
 
 func NewFrameAnimation(frames []Frame, loop bool, target *int) *FrameAnimation {
 	var f = &FrameAnimation{
 		loop:   loop,
 		target: target,
+		events: &Events{},
 	}
 	f.SetFrames(frames)
 	return f
 }
 
 func (a *FrameAnimation) IsDone() bool {
-	return !a.loop && a.Elapsed >= a.Duration
+	return !a.loop && a.Elapsed >= a.Length()
 }
 
 func (a *FrameAnimation) SetCallback(callback AnimatorCallback) {
-	a.callback = callback
+	a.Events().setCallback(callback)
 }
 
-func (a *FrameAnimation) Update(elapsed time.Duration) time.Duration {
-	a.Elapsed += elapsed
-	elapsed += a.remainder
-	for elapsed > 0 {
-		if elapsed >= a.sequence[a.current].Duration {
-			elapsed -= a.sequence[a.current].Duration
-			a.current += 1
-			if a.current >= len(a.sequence) {
-				if a.loop {
-					a.current = a.current % len(a.sequence)
-				} else {
-					a.current = len(a.sequence) - 1
-				}
-			}
-		} else {
-			a.remainder = elapsed
-			elapsed = 0
-		}
+func (a *FrameAnimation) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+// Adds an event to the frame at a position in the sequence.  Events stay on
+// their position when the frames are replaced, so an event past the end of
+// the sequence only fires once SetFrames provides that position.  Negative
+// positions are ignored.
+func (a *FrameAnimation) AddFrameEvent(frame int, name string, payload any) {
+	if frame < 0 {
+		return
 	}
-	if a.target != nil {
-		*a.target = a.sequence[a.current].Index
+	if a.marks == nil {
+		a.marks = map[int][]FrameEvent{}
 	}
+	a.marks[frame] = append(a.marks[frame], FrameEvent{name, payload})
+}
+
+// Subscribes to the events on frames.  Each frame raises its events when it
+// is shown, in order, including frames skipped over by a large update and
+// frames passed while looping around.
+func (a *FrameAnimation) OnFrameEvent(callback FrameEventCallback) Subscription {
+	return subscribe(&a.listeners, callback)
+}
+
+// Negative elapsed time moves the animation back towards its start.
+func (a *FrameAnimation) Update(elapsed time.Duration) time.Duration {
+	a.Elapsed = max(a.Elapsed+elapsed, 0)
+	a.current = a.frameAt(a.Elapsed)
+	a.show()
+	a.play()
+	a.events.update(a.IsDone())
 	if a.IsDone() {
-		if a.callback != nil {
-			a.callback()
-		}
-		return a.Elapsed - a.Duration
+		return a.Elapsed - a.Length()
 	}
 	return 0
 }
 
+// Writes the index of the current frame to the targets.
+func (a *FrameAnimation) show() {
+	if len(a.sequence) == 0 {
+		return
+	}
+	var index = a.sequence[a.current].Index
+	if a.target != nil {
+		*a.target = index
+	}
+	if a.output != nil {
+		a.output(index)
+	}
+}
+
 func (a *FrameAnimation) Reset() {
-	a.current = 0
 	a.Elapsed = 0
+	a.current = a.frameAt(0)
+	a.fired = -1
+	a.events.reset()
+}
+
+func (a *FrameAnimation) Delete() {
+	a.events.delete()
+}
+
+func (a *FrameAnimation) Seek(position time.Duration, mode SeekMode) time.Duration {
+	a.Elapsed = max(position, 0)
+	a.current = a.frameAt(a.Elapsed)
+	a.show()
+	if mode == SeekReplay {
+		a.play()
+	} else {
+		a.fired = a.Elapsed
+	}
+	a.events.seek(a.IsDone(), mode)
+	if a.IsDone() {
+		return a.Elapsed - a.Length()
+	}
+	return 0
+}
+
+func (a *FrameAnimation) Position() time.Duration {
+	return a.Elapsed
+}
+
+func (a *FrameAnimation) Length() time.Duration {
+	return passLength(a.Duration, a.direction)
+}
+
+func (a *FrameAnimation) SetDirection(direction Direction) {
+	a.direction = direction
+}
+
+func (a *FrameAnimation) Direction() Direction {
+	return a.direction
+}
+
+// Returns the index into the sequence of the frame shown after travelling
+// for the given time.  Frames are shown for their full duration in either
+// direction, so moving backwards a frame lasts from its end down to just
+// after its start.
+func (a *FrameAnimation) frameAt(travel time.Duration) int {
+	var (
+		count               = len(a.sequence)
+		position, backwards = playhead(travel, a.Duration, a.direction, a.loop)
+		start, end          time.Duration
+	)
+	if count == 0 {
+		return 0
+	}
+	if backwards {
+		end = a.Duration
+		for i := count - 1; i > 0; i-- {
+			start = end - a.sequence[i].Duration
+			if position > start {
+				return i
+			}
+			end = start
+		}
+		return 0
+	}
+	for i := 0; i < count-1; i++ {
+		end += a.sequence[i].Duration
+		if position < end {
+			return i
+		}
+	}
+	return count - 1
+}
+
+type frameEntry struct {
+	at    time.Duration
+	frame int
+}
+
+// Returns when each frame starts to be shown during one pass, in order.
+// Ping-pong animations keep showing the end frames as they turn around, so
+// they are entered only once.  The first frame is entered at the end of a
+// pass, and so is not entered again as a looping animation starts the next.
+func (a *FrameAnimation) entries() []frameEntry {
+	var (
+		count   = len(a.sequence)
+		ends    = make([]time.Duration, count)
+		end     time.Duration
+		entries = make([]frameEntry, 0, 2*count)
+	)
+	for i, frame := range a.sequence {
+		end += frame.Duration
+		ends[i] = end
+	}
+	if a.direction == Reverse {
+		for i := count - 1; i >= 0; i-- {
+			entries = append(entries, frameEntry{a.Duration - ends[i], i})
+		}
+		return entries
+	}
+	for i := range a.sequence {
+		entries = append(entries, frameEntry{ends[i] - a.sequence[i].Duration, i})
+	}
+	if a.direction == PingPong {
+		for i := count - 2; i >= 0; i-- {
+			entries = append(entries, frameEntry{2*a.Duration - ends[i], i})
+		}
+	}
+	return entries
 }
 
-func (a *FrameAnimation) Delete() {}
+// Raises the loop and frame events passed since they were last raised, up to
+// Elapsed.  Moving backwards raises nothing.
+func (a *FrameAnimation) play() {
+	var (
+		pass   = a.Length()
+		travel = a.Elapsed
+	)
+	if travel <= a.fired || pass <= 0 {
+		a.fired = travel
+		return
+	}
+	var (
+		entries = a.entries()
+		turns   = a.direction == PingPong && len(a.sequence) > 1
+	)
+	for base := max(a.fired, 0) / pass * pass; base <= travel; base += pass {
+		if base > 0 && !a.loop {
+			break
+		}
+		if base > 0 && base > a.fired {
+			a.events.loop()
+		}
+		for i, entry := range entries {
+			if i == 0 && base > 0 && turns {
+				continue
+			}
+			if at := base + entry.at; at > a.fired && at <= travel {
+				a.raise(entry.frame)
+			}
+		}
+	}
+	a.fired = travel
+}
+
+func (a *FrameAnimation) raise(position int) {
+	for _, event := range a.marks[position] {
+		for _, l := range a.listeners {
+			if !l.removed {
+				l.callback(a.sequence[position], event)
+			}
+		}
+	}
+}
 
 func (a *FrameAnimation) SetFrames(frames []Frame) {
 	var (
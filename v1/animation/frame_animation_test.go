@@ -15,6 +15,7 @@
 package animation
 
 import (
+	"fmt"
 	"testing"
 	"time"
 )
@@ -106,3 +107,225 @@ func TestNonLoopFrameAnimation(t *testing.T) {
 		t.Fatalf("Current frame does not match expected, got %v", target)
 	}
 }
+
+// Tests that landing exactly on a frame boundary does not carry time over.
+func TestFrameAnimationExactBoundary(t *testing.T) {
+	var (
+		target int = 0
+		frames     = []Frame{MsFrame(100, 0), MsFrame(100, 1), MsFrame(100, 2)}
+		anim       = NewFrameAnimation(frames, true, &target)
+	)
+	anim.Update(50 * time.Millisecond)
+	anim.Update(50 * time.Millisecond)
+	if target != 1 {
+		t.Fatalf("Current frame does not match expected, got %v", target)
+	}
+	anim.Update(50 * time.Millisecond)
+	if target != 1 {
+		t.Fatalf("Current frame does not match expected, got %v", target)
+	}
+}
+
+// Tests that seeking selects the frame at the new position.
+func TestFrameAnimationSeek(t *testing.T) {
+	var (
+		target int  = 0
+		done   bool = false
+		frames      = []Frame{MsFrame(100, 0), MsFrame(100, 2), MsFrame(100, 1), MsFrame(100, 3)}
+		anim        = NewFrameAnimation(frames, false, &target)
+	)
+	anim.SetCallback(func() { done = true })
+	anim.Seek(250*time.Millisecond, SeekReplay)
+	if target != 1 || anim.Position() != 250*time.Millisecond {
+		t.Fatalf("Current frame does not match expected, got %v", target)
+	}
+	anim.Update(60 * time.Millisecond)
+	if target != 3 {
+		t.Fatalf("Current frame does not match expected, got %v", target)
+	}
+	if anim.Seek(450*time.Millisecond, SeekSilent) != 50*time.Millisecond || target != 3 || done {
+		t.Fatalf("FrameAnimation.Seek past the end did not finish silently")
+	}
+	anim.Seek(0, SeekSilent)
+	anim.Seek(400*time.Millisecond, SeekReplay)
+	if !done {
+		t.Fatalf("FrameAnimation.Seek did not replay crossed callback")
+	}
+	anim = NewFrameAnimation(frames, true, &target)
+	anim.Seek(1450*time.Millisecond, SeekSilent)
+	if target != 1 {
+		t.Fatalf("Looping FrameAnimation.Seek did not wrap, got %v", target)
+	}
+}
+
+// Tests that a reversed animation shows frames last to first and calls the
+// callback at the start.
+func TestReverseFrameAnimation(t *testing.T) {
+	var (
+		target int  = 0
+		done   bool = false
+		frames      = []Frame{MsFrame(100, 0), MsFrame(100, 2), MsFrame(100, 1), MsFrame(100, 3)}
+		anim        = NewFrameAnimation(frames, false, &target)
+	)
+	anim.SetCallback(func() { done = true })
+	anim.SetDirection(Reverse)
+	anim.Reset()
+	for _, expected := range []int{3, 1, 2, 0} {
+		anim.Update(50 * time.Millisecond)
+		if target != expected {
+			t.Fatalf("Current frame does not match expected %v, got %v", expected, target)
+		}
+		anim.Update(50 * time.Millisecond)
+	}
+	if !anim.IsDone() || !done || target != 0 {
+		t.Fatalf("Reversed animation did not finish at the first frame")
+	}
+}
+
+// Tests that a ping-pong animation plays out and back, and loops.
+func TestPingPongFrameAnimation(t *testing.T) {
+	var (
+		target int = 0
+		frames     = []Frame{MsFrame(100, 0), MsFrame(100, 1), MsFrame(100, 2)}
+		anim       = NewFrameAnimation(frames, true, &target)
+		seen       = []int{}
+	)
+	anim.SetDirection(PingPong)
+	for i := 0; i < 8; i++ {
+		anim.Update(100 * time.Millisecond)
+		seen = append(seen, target)
+	}
+	var expected = []int{1, 2, 2, 1, 0, 0, 1, 2}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("Ping-pong frames were %v, expected %v", seen, expected)
+		}
+	}
+	if anim.IsDone() {
+		t.Fatalf("Looping animation marked done (should not)")
+	}
+}
+
+// Tests that negative elapsed time steps back through frames.
+func TestFrameAnimationNegativeElapsed(t *testing.T) {
+	var (
+		target int = 0
+		frames     = []Frame{MsFrame(100, 0), MsFrame(100, 2), MsFrame(100, 1), MsFrame(100, 3)}
+		anim       = NewFrameAnimation(frames, false, &target)
+	)
+	anim.Update(450 * time.Millisecond)
+	if !anim.IsDone() {
+		t.Fatalf("Non-looping animation not marked done when finished")
+	}
+	anim.Update(-200 * time.Millisecond)
+	if target != 1 || anim.IsDone() {
+		t.Fatalf("Current frame does not match expected, got %v", target)
+	}
+	anim.Update(-1 * time.Second)
+	if target != 0 || anim.Elapsed != 0 {
+		t.Fatalf("Current frame does not match expected, got %v", target)
+	}
+}
+
+// Tests that frame events are raised in order, including skipped frames and
+// frames passed while looping around.
+func TestFrameEvents(t *testing.T) {
+	var (
+		frames = []Frame{MsFrame(100, 0), MsFrame(100, 1), MsFrame(100, 2), MsFrame(100, 3)}
+		anim   = NewFrameAnimation(frames, true, nil)
+		seen   = []string{}
+	)
+	anim.AddFrameEvent(0, "start", nil)
+	anim.AddFrameEvent(1, "step", "left")
+	anim.AddFrameEvent(3, "step", "right")
+	anim.AddFrameEvent(3, "hit", 5)
+	anim.Events().OnLoop(func() { seen = append(seen, "loop") })
+	anim.OnFrameEvent(func(frame Frame, event FrameEvent) {
+		seen = append(seen, fmt.Sprintf("%v:%v:%v", frame.Index, event.Name, event.Payload))
+	})
+	anim.Update(50 * time.Millisecond)
+	anim.Update(50 * time.Millisecond)
+	anim.Update(600 * time.Millisecond)
+	var expected = []string{
+		"0:start:<nil>", "1:step:left", "3:step:right", "3:hit:5",
+		"loop", "0:start:<nil>", "1:step:left", "3:step:right", "3:hit:5",
+	}
+	if fmt.Sprint(seen) != fmt.Sprint(expected) {
+		t.Fatalf("Frame events were %v, expected %v", seen, expected)
+	}
+	seen = seen[:0]
+	anim.Update(-450 * time.Millisecond)
+	anim.Update(100 * time.Millisecond)
+	if fmt.Sprint(seen) != "[3:step:right 3:hit:5]" {
+		t.Fatalf("Frame events after rewinding were %v", seen)
+	}
+}
+
+// Tests that a ping-pong animation raises the events of the turning frame
+// once per pass.
+func TestPingPongFrameEvents(t *testing.T) {
+	var (
+		frames = []Frame{MsFrame(100, 0), MsFrame(100, 1), MsFrame(100, 2)}
+		anim   = NewFrameAnimation(frames, false, nil)
+		seen   = []string{}
+		sub    Subscription
+	)
+	anim.AddFrameEvent(0, "a", nil)
+	anim.AddFrameEvent(1, "b", nil)
+	anim.AddFrameEvent(2, "c", nil)
+	anim.SetDirection(PingPong)
+	sub = anim.OnFrameEvent(func(frame Frame, event FrameEvent) {
+		seen = append(seen, event.Name)
+	})
+	anim.Update(1 * time.Second)
+	if fmt.Sprint(seen) != "[a b c b a]" {
+		t.Fatalf("Ping-pong frame events were %v", seen)
+	}
+	sub.Unsubscribe()
+	anim.Reset()
+	anim.Update(1 * time.Second)
+	if len(seen) != 5 {
+		t.Fatalf("Unsubscribed callback was called")
+	}
+}
+
+// Tests that a looping ping-pong animation raises the events of the first
+// frame once as it turns around.
+func TestLoopingPingPongFrameEvents(t *testing.T) {
+	var (
+		frames = []Frame{MsFrame(100, 0), MsFrame(100, 1), MsFrame(100, 2)}
+		anim   = NewFrameAnimation(frames, true, nil)
+		seen   = []int{}
+	)
+	anim.SetDirection(PingPong)
+	anim.AddFrameEvent(0, "a", nil)
+	anim.AddFrameEvent(1, "b", nil)
+	anim.AddFrameEvent(2, "c", nil)
+	anim.AddFrameEvent(-1, "d", nil)
+	anim.OnFrameEvent(func(frame Frame, event FrameEvent) {
+		seen = append(seen, frame.Index)
+	})
+	anim.Update(50 * time.Millisecond)
+	anim.Update(600 * time.Millisecond)
+	anim.Update(700 * time.Millisecond)
+	if fmt.Sprint(seen) != "[0 1 2 1 0 1 2 1 0 1]" {
+		t.Fatalf("Looping ping-pong frame events were %v", seen)
+	}
+}
+
+// Tests that frame events stay on their position when the frames change.
+func TestFrameEventsSetFrames(t *testing.T) {
+	var (
+		anim = NewFrameAnimation([]Frame{MsFrame(100, 0), MsFrame(100, 1)}, false, nil)
+		seen = []int{}
+	)
+	anim.AddFrameEvent(1, "a", nil)
+	anim.OnFrameEvent(func(frame Frame, event FrameEvent) {
+		seen = append(seen, frame.Index)
+	})
+	anim.SetFrames([]Frame{MsFrame(100, 5), MsFrame(100, 6), MsFrame(100, 7)})
+	anim.Update(1 * time.Second)
+	if fmt.Sprint(seen) != "[6]" {
+		t.Fatalf("Frame events after SetFrames were %v", seen)
+	}
+}
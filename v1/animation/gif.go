@@ -0,0 +1,159 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"time"
+)
+
+// The unit GIF frame delays are given in.
+const gifDelayUnit = 10 * time.Millisecond
+
+// Renders frames cut from a sprite sheet as an animated GIF, which loops
+// forever if loop is set and otherwise plays once.  The cell at each frame's
+// Index gives where the frame is in the sheet.  Encode the result with
+// gif.EncodeAll.
+func FramesGIF(sheet image.Image, cells []image.Rectangle, frames []Frame, loop bool) (*gif.GIF, error) {
+	var shown = make([]frameShown, 0, len(frames))
+	for _, frame := range frames {
+		shown = append(shown, frameShown{frame, frame.Duration})
+	}
+	return renderGIF(sheet, cells, shown, loop)
+}
+
+// Renders one pass of a frame animation as an animated GIF, in the
+// animation's direction.
+func FrameAnimationGIF(sheet image.Image, cells []image.Rectangle, anim *FrameAnimation) (*gif.GIF, error) {
+	var (
+		entries = anim.entries()
+		shown   = make([]frameShown, 0, len(entries))
+	)
+	for i, entry := range entries {
+		var end = anim.Length()
+		if i+1 < len(entries) {
+			end = entries[i+1].at
+		}
+		shown = append(shown, frameShown{anim.sequence[entry.frame], end - entry.at})
+	}
+	return renderGIF(sheet, cells, shown, anim.loop)
+}
+
+// A frame and how long it stays on screen.
+type frameShown struct {
+	frame    Frame
+	duration time.Duration
+}
+
+func renderGIF(sheet image.Image, cells []image.Rectangle, shown []frameShown, loop bool) (*gif.GIF, error) {
+	var (
+		result    = &gif.GIF{LoopCount: -1}
+		durations []time.Duration
+		images    []image.Image
+		width     int
+		height    int
+	)
+	if loop {
+		result.LoopCount = 0
+	}
+	for _, s := range shown {
+		// Frames without a duration are never shown.
+		if s.duration <= 0 {
+			continue
+		}
+		if s.frame.Index < 0 || s.frame.Index >= len(cells) {
+			return nil, fmt.Errorf("frame index %v has no cell", s.frame.Index)
+		}
+		var cell = cells[s.frame.Index].Intersect(sheet.Bounds())
+		if cell.Empty() {
+			return nil, fmt.Errorf("cell %v of frame index %v is outside the sheet", cells[s.frame.Index], s.frame.Index)
+		}
+		width = max(width, cell.Dx())
+		height = max(height, cell.Dy())
+		images = append(images, subImage(sheet, cell))
+		durations = append(durations, s.duration)
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no frames to render")
+	}
+	var colors, exact = gifPalette(images)
+	for _, img := range images {
+		var paletted = image.NewPaletted(image.Rect(0, 0, img.Bounds().Dx(), img.Bounds().Dy()), colors)
+		if exact {
+			draw.Draw(paletted, paletted.Bounds(), img, img.Bounds().Min, draw.Src)
+		} else {
+			draw.FloydSteinberg.Draw(paletted, paletted.Bounds(), img, img.Bounds().Min)
+		}
+		result.Image = append(result.Image, paletted)
+		result.Disposal = append(result.Disposal, gif.DisposalBackground)
+	}
+	result.Delay = gifDelays(durations)
+	result.Config = image.Config{ColorModel: colors, Width: width, Height: height}
+	return result, nil
+}
+
+// Rounds durations to GIF delays.  Each delay is chosen so that the frame
+// ends as close as possible to when it should, which keeps rounding errors
+// from adding up over a long animation.  Delays are at least one unit, as
+// viewers slow down frames without a delay.
+func gifDelays(durations []time.Duration) []int {
+	var (
+		delays  = make([]int, len(durations))
+		elapsed time.Duration
+		emitted int
+	)
+	for i, duration := range durations {
+		elapsed += duration
+		var end = int((elapsed + gifDelayUnit/2) / gifDelayUnit)
+		delays[i] = max(end-emitted, 1)
+		emitted += delays[i]
+	}
+	return delays
+}
+
+// Returns the colors used by the images if they fit in a GIF palette, with
+// fully transparent pixels sharing one entry.  Otherwise returns a general
+// palette to dither to, and reports that it is not exact.
+func gifPalette(images []image.Image) (color.Palette, bool) {
+	var (
+		colors = color.Palette{}
+		seen   = map[color.RGBA]bool{}
+	)
+	for _, img := range images {
+		var bounds = img.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				var c = color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+				if c.A == 0 {
+					c = color.RGBA{}
+				}
+				if seen[c] {
+					continue
+				}
+				if len(colors) == 256 {
+					return palette.Plan9, false
+				}
+				seen[c] = true
+				colors = append(colors, c)
+			}
+		}
+	}
+	return colors, true
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"slices"
+	"testing"
+	"time"
+)
+
+var (
+	gifRed   = color.RGBA{255, 0, 0, 255}
+	gifGreen = color.RGBA{0, 255, 0, 255}
+	gifBlue  = color.RGBA{0, 0, 255, 255}
+)
+
+// Returns a sheet of 2x2 cells in a row, each filled with one color.
+func gifSheet(colors ...color.RGBA) (*image.RGBA, []image.Rectangle) {
+	var (
+		sheet = image.NewRGBA(image.Rect(0, 0, 2*len(colors), 2))
+		cells []image.Rectangle
+	)
+	for i, c := range colors {
+		var cell = image.Rect(2*i, 0, 2*i+2, 2)
+		for y := 0; y < 2; y++ {
+			for x := cell.Min.X; x < cell.Max.X; x++ {
+				sheet.SetRGBA(x, y, c)
+			}
+		}
+		cells = append(cells, cell)
+	}
+	return sheet, cells
+}
+
+func TestGIFDelays(t *testing.T) {
+	var tests = []struct {
+		durations []time.Duration
+		delays    []int
+	}{
+		{[]time.Duration{100 * time.Millisecond, 250 * time.Millisecond}, []int{10, 25}},
+		{[]time.Duration{33 * time.Millisecond, 33 * time.Millisecond, 33 * time.Millisecond}, []int{3, 4, 3}},
+		{[]time.Duration{time.Second / 12, time.Second / 12, time.Second / 12, time.Second / 12}, []int{8, 9, 8, 8}},
+		{[]time.Duration{2 * time.Millisecond, 2 * time.Millisecond, 16 * time.Millisecond}, []int{1, 1, 1}},
+	}
+	for _, test := range tests {
+		if delays := gifDelays(test.durations); !slices.Equal(delays, test.delays) {
+			t.Fatalf("Expected delays %v for %v, got %v", test.delays, test.durations, delays)
+		}
+	}
+}
+
+func TestFramesGIF(t *testing.T) {
+	var (
+		sheet, cells = gifSheet(gifRed, gifGreen, gifBlue)
+		frames       = []Frame{MsFrame(100, 2), MsFrame(0, 1), MsFrame(50, 0)}
+		result       *gif.GIF
+		err          error
+	)
+	if result, err = FramesGIF(sheet, cells, frames, false); err != nil {
+		t.Fatalf("Could not render GIF: %v", err)
+	}
+	if result.LoopCount != -1 || !slices.Equal(result.Delay, []int{10, 5}) {
+		t.Fatalf("Expected one play with delays [10 5], got %v %v", result.LoopCount, result.Delay)
+	}
+	if result.Config.Width != 2 || result.Image[0].At(1, 1) != gifBlue || result.Image[1].At(0, 0) != gifRed {
+		t.Fatalf("Frames were not cut from the sheet")
+	}
+	var buffer bytes.Buffer
+	if err = gif.EncodeAll(&buffer, result); err != nil {
+		t.Fatalf("Could not encode GIF: %v", err)
+	}
+	var decoded, _ = gif.DecodeAll(&buffer)
+	if decoded == nil || len(decoded.Image) != 2 || decoded.LoopCount != -1 {
+		t.Fatalf("GIF did not round trip")
+	}
+	if _, err = FramesGIF(sheet, cells, []Frame{MsFrame(100, 3)}, false); err == nil {
+		t.Fatalf("Expected an error for a frame without a cell")
+	}
+}
+
+func TestFrameAnimationGIF(t *testing.T) {
+	var (
+		sheet, cells = gifSheet(gifRed, gifGreen, gifBlue)
+		anim         = NewFrameAnimation([]Frame{MsFrame(100, 0), MsFrame(100, 1), MsFrame(50, 2)}, true, nil)
+	)
+	anim.SetDirection(PingPong)
+	var result, err = FrameAnimationGIF(sheet, cells, anim)
+	if err != nil {
+		t.Fatalf("Could not render GIF: %v", err)
+	}
+	if result.LoopCount != 0 || !slices.Equal(result.Delay, []int{10, 10, 10, 10, 10}) {
+		t.Fatalf("Expected a looping ping pong, got %v %v", result.LoopCount, result.Delay)
+	}
+	if result.Image[2].At(0, 0) != gifBlue || result.Image[3].At(0, 0) != gifGreen || result.Image[4].At(0, 0) != gifRed {
+		t.Fatalf("Expected the frames to play back after the turn")
+	}
+}
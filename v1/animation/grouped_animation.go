@@ -20,15 +20,21 @@ import (
 
 type GroupedAnimation struct {
 	animators []Animator
-	callback  AnimatorCallback
+	events    *Events
+	direction Direction
+	scale     timeScale
 }
 
 func NewGroupedAnimation(animators []Animator) *GroupedAnimation {
-	return &GroupedAnimation{animators, nil}
+	return &GroupedAnimation{animators, &Events{}, Forward, newTimeScale(1)}
 }
 
 func (a *GroupedAnimation) SetCallback(callback AnimatorCallback) {
-	a.callback = callback
+	a.Events().setCallback(callback)
+}
+
+func (a *GroupedAnimation) Events() *Events {
+	return ensureEvents(&a.events)
 }
 
 func (a *GroupedAnimation) IsDone() bool {
@@ -41,12 +47,30 @@ func (a *GroupedAnimation) IsDone() bool {
 	return done
 }
 
+// Negative elapsed time moves the children back towards their starts.  It is
+// ignored if any child is not a Measurable Seeker.
 func (a *GroupedAnimation) Update(elapsed time.Duration) time.Duration {
+	var remainder = a.advance(elapsed)
+	a.events.update(a.IsDone())
+	return remainder
+}
+
+func (a *GroupedAnimation) advance(elapsed time.Duration) time.Duration {
 	var (
 		total     time.Duration
 		remainder time.Duration
 		done      = true
 	)
+	if elapsed < 0 && !a.canRewind() {
+		return 0
+	}
+	if a.scale.frozen() {
+		if a.IsDone() {
+			return elapsed
+		}
+		return 0
+	}
+	elapsed = a.scale.toChild(elapsed)
 	for _, animator := range a.animators {
 		remainder = animator.Update(elapsed)
 		if !animator.IsDone() {
@@ -57,23 +81,103 @@ func (a *GroupedAnimation) Update(elapsed time.Duration) time.Duration {
 		}
 	}
 	if done {
-		if a.callback != nil {
-			a.callback()
-		}
-		return total
+		return a.scale.toParent(total)
 	}
 	return 0
 }
 
+func (a *GroupedAnimation) canRewind() bool {
+	return canRewindAll(a.animators)
+}
+
 func (a *GroupedAnimation) Reset() {
 	for _, animator := range a.animators {
 		animator.Reset()
 	}
+	a.events.reset()
 }
 
 func (a *GroupedAnimation) Delete() {
+	a.events.delete()
 	for _, animator := range a.animators {
 		animator.Delete()
 	}
 	a.animators = []Animator{}
 }
+
+func (a *GroupedAnimation) Seek(position time.Duration, mode SeekMode) time.Duration {
+	var (
+		total     time.Duration
+		remainder time.Duration
+	)
+	position = a.scale.toChild(position)
+	for _, animator := range a.animators {
+		remainder = SeekAnimator(animator, position, mode)
+		if remainder != 0 && (total == 0 || remainder < total) {
+			total = remainder // Take the smallest nonzero remainder.
+		}
+	}
+	a.events.seek(a.IsDone(), mode)
+	if a.IsDone() {
+		return a.scale.toParent(total)
+	}
+	return 0
+}
+
+// Returns the position of the furthest child.
+func (a *GroupedAnimation) Position() time.Duration {
+	var position time.Duration
+	for _, animator := range a.animators {
+		if p := PositionOf(animator); p > position {
+			position = p
+		}
+	}
+	return a.scale.toParent(position)
+}
+
+// Applies the direction to each Directional child.  The children still start
+// together, so in reverse they all begin from their ends at once.
+func (a *GroupedAnimation) SetDirection(direction Direction) {
+	a.direction = direction
+	for _, animator := range a.animators {
+		SetAnimatorDirection(animator, direction)
+	}
+}
+
+func (a *GroupedAnimation) Direction() Direction {
+	return a.direction
+}
+
+// Runs the children at a multiple of the elapsed time.  Positions, lengths
+// and remainders are all reported in unscaled time.
+func (a *GroupedAnimation) SetTimeScale(scale float64) {
+	a.scale.set(scale)
+}
+
+func (a *GroupedAnimation) TimeScale() float64 {
+	return a.scale.scale
+}
+
+// Stops the children from advancing until Resume is called.
+func (a *GroupedAnimation) Pause() {
+	a.scale.paused = true
+}
+
+func (a *GroupedAnimation) Resume() {
+	a.scale.paused = false
+}
+
+func (a *GroupedAnimation) IsPaused() bool {
+	return a.scale.paused
+}
+
+// Returns the length of the longest child.
+func (a *GroupedAnimation) Length() time.Duration {
+	var length time.Duration
+	for _, animator := range a.animators {
+		if l := LengthOf(animator); l > length {
+			length = l
+		}
+	}
+	return a.scale.toParent(length)
+}
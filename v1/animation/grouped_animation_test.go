@@ -22,9 +22,9 @@ import (
 func TestGroupedAnimationSetCallback(t *testing.T) {
 	var (
 		done   = false
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = GroupedAnimation{[]Animator{child1, child2}, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewGroupedAnimation([]Animator{child1, child2})
 		cb     = func() { done = true }
 	)
 	anim.SetCallback(cb)
@@ -55,9 +55,9 @@ func TestGroupedAnimationSetCallback(t *testing.T) {
 
 func TestGroupedAnimationIsDone(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = GroupedAnimation{[]Animator{child1, child2}, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewGroupedAnimation([]Animator{child1, child2})
 	)
 	anim.Update(1 * time.Second)
 	if anim.IsDone() {
@@ -71,9 +71,9 @@ func TestGroupedAnimationIsDone(t *testing.T) {
 
 func TestGroupedAnimationUpdate(t *testing.T) {
 	var (
-		child1               = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2               = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim                 = GroupedAnimation{[]Animator{child1, child2}, nil}
+		child1               = &BoundedAnimation{Duration: 1 * time.Second}
+		child2               = &BoundedAnimation{Duration: 2 * time.Second}
+		anim                 = NewGroupedAnimation([]Animator{child1, child2})
 		resp   time.Duration = 0
 	)
 	resp = anim.Update(1 * time.Second)
@@ -92,9 +92,9 @@ func TestGroupedAnimationUpdate(t *testing.T) {
 
 func TestGroupedAnimationReset(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{200 * time.Millisecond, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{1200 * time.Millisecond, 2 * time.Second, nil}
-		anim   = GroupedAnimation{[]Animator{child1, child2}, nil}
+		child1 = &BoundedAnimation{Elapsed: 200 * time.Millisecond, Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Elapsed: 1200 * time.Millisecond, Duration: 2 * time.Second}
+		anim   = NewGroupedAnimation([]Animator{child1, child2})
 	)
 	anim.Reset()
 	if child1.Elapsed != 0 {
@@ -107,9 +107,9 @@ func TestGroupedAnimationReset(t *testing.T) {
 
 func TestGroupedAnimationDelete(t *testing.T) {
 	var (
-		child1 = &BoundedAnimation{0, 1 * time.Second, nil}
-		child2 = &BoundedAnimation{0, 2 * time.Second, nil}
-		anim   = GroupedAnimation{[]Animator{child1, child2}, nil}
+		child1 = &BoundedAnimation{Duration: 1 * time.Second}
+		child2 = &BoundedAnimation{Duration: 2 * time.Second}
+		anim   = NewGroupedAnimation([]Animator{child1, child2})
 	)
 	anim.Delete()
 	anim.Update(100 * time.Millisecond)
@@ -117,3 +117,52 @@ func TestGroupedAnimationDelete(t *testing.T) {
 		t.Fatalf("GroupedAnimation.Delete must remove references to children")
 	}
 }
+
+func TestGroupedAnimationSeek(t *testing.T) {
+	var (
+		done   = false
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(2 * time.Second)
+		anim   = NewGroupedAnimation([]Animator{child1, child2})
+	)
+	anim.SetCallback(func() { done = true })
+	if anim.Seek(1500*time.Millisecond, SeekReplay) != 0 || anim.IsDone() {
+		t.Fatalf("GroupedAnimation.Seek finished too early")
+	}
+	if child1.Elapsed != 1500*time.Millisecond || child2.Elapsed != 1500*time.Millisecond {
+		t.Fatalf("GroupedAnimation.Seek did not position children")
+	}
+	if anim.Seek(2100*time.Millisecond, SeekSilent) != 100*time.Millisecond || done {
+		t.Fatalf("GroupedAnimation.Seek did not finish silently")
+	}
+	anim.Seek(0, SeekSilent)
+	anim.Seek(2*time.Second, SeekReplay)
+	if !done || anim.Position() != 2*time.Second {
+		t.Fatalf("GroupedAnimation.Seek did not replay crossed callback")
+	}
+}
+
+func TestGroupedAnimationDirection(t *testing.T) {
+	var (
+		dest   float32
+		child1 = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest)
+		child2 = NewBoundedAnimation(2 * time.Second)
+		anim   = NewGroupedAnimation([]Animator{child1, child2})
+	)
+	anim.SetDirection(Reverse)
+	if child1.Direction() != Reverse || child2.Direction() != Reverse {
+		t.Fatalf("GroupedAnimation.SetDirection did not apply to children")
+	}
+	anim.Update(250 * time.Millisecond)
+	if dest != 7.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.SetDirection(PingPong)
+	anim.Reset()
+	if anim.Length() != 4*time.Second {
+		t.Fatalf("GroupedAnimation.Length was %v", anim.Length())
+	}
+	if anim.Update(4500*time.Millisecond) != 500*time.Millisecond || dest != 0 {
+		t.Fatalf("GroupedAnimation did not finish after both legs")
+	}
+}
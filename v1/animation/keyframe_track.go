@@ -0,0 +1,226 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"sort"
+	"time"
+)
+
+// How a keyframe moves towards the next one.
+type Interpolation int
+
+const (
+	InterpolateLinear Interpolation = iota
+	InterpolateStep
+	InterpolateEased
+	InterpolateHermite
+	InterpolateCatmullRom
+)
+
+// A value at a point in time.  Interpolation and Easing describe the segment
+// which starts at this key.  InTangent and OutTangent are slopes in units per
+// second, used by InterpolateHermite.
+type Keyframe struct {
+	Time          time.Duration
+	Value         float32
+	Interpolation Interpolation
+	Easing        Easing
+	InTangent     float32
+	OutTangent    float32
+}
+
+// Animates a value through a sequence of keyframes.
+type KeyframeTrack struct {
+	Elapsed   time.Duration
+	Duration  time.Duration
+	events    *Events
+	keys      []Keyframe
+	loop      bool
+	target    *float32
+	direction Direction
+}
+
+func NewKeyframeTrack(keys []Keyframe, loop bool, target *float32) *KeyframeTrack {
+	var a = &KeyframeTrack{
+		loop:   loop,
+		target: target,
+		events: &Events{},
+	}
+	a.SetKeyframes(keys)
+	return a
+}
+
+func (a *KeyframeTrack) IsDone() bool {
+	return !a.loop && a.Elapsed >= a.Length()
+}
+
+func (a *KeyframeTrack) SetCallback(callback AnimatorCallback) {
+	a.Events().setCallback(callback)
+}
+
+func (a *KeyframeTrack) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+// Negative elapsed time moves the animation back towards its start.
+func (a *KeyframeTrack) Update(elapsed time.Duration) time.Duration {
+	var previous = a.Elapsed
+	a.Elapsed = max(a.Elapsed+elapsed, 0)
+	if a.target != nil {
+		*a.target = a.currentValue()
+	}
+	if a.loop {
+		a.events.loops(previous, a.Elapsed, a.Length())
+	}
+	a.events.update(a.IsDone())
+	if a.IsDone() {
+		return a.Elapsed - a.Length()
+	}
+	return 0
+}
+
+func (a *KeyframeTrack) Reset() {
+	a.Elapsed = 0
+	a.events.reset()
+}
+
+func (a *KeyframeTrack) Delete() {
+	a.events.delete()
+}
+
+func (a *KeyframeTrack) Seek(position time.Duration, mode SeekMode) time.Duration {
+	a.Elapsed = max(position, 0)
+	if a.target != nil {
+		*a.target = a.currentValue()
+	}
+	a.events.seek(a.IsDone(), mode)
+	if a.IsDone() {
+		return a.Elapsed - a.Length()
+	}
+	return 0
+}
+
+func (a *KeyframeTrack) Position() time.Duration {
+	return a.Elapsed
+}
+
+func (a *KeyframeTrack) Length() time.Duration {
+	return passLength(a.Duration, a.direction)
+}
+
+func (a *KeyframeTrack) SetDirection(direction Direction) {
+	a.direction = direction
+}
+
+func (a *KeyframeTrack) Direction() Direction {
+	return a.direction
+}
+
+func (a *KeyframeTrack) currentValue() float32 {
+	var position, _ = playhead(a.Elapsed, a.Duration, a.direction, a.loop)
+	return a.valueAt(position)
+}
+
+// Replaces the keyframes, which are sorted by time.  The track lasts until
+// the last keyframe.
+func (a *KeyframeTrack) SetKeyframes(keys []Keyframe) {
+	var sorted = make([]Keyframe, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time < sorted[j].Time
+	})
+	a.keys = sorted
+	a.Duration = 0
+	if len(sorted) > 0 {
+		a.Duration = sorted[len(sorted)-1].Time
+	}
+	a.Reset()
+}
+
+// Returns the value of the track at any time without changing its state.
+func (a *KeyframeTrack) ValueAt(elapsed time.Duration) float32 {
+	if a.loop && a.Duration > 0 {
+		elapsed %= a.Duration
+		if elapsed < 0 {
+			elapsed += a.Duration
+		}
+	}
+	return a.valueAt(elapsed)
+}
+
+func (a *KeyframeTrack) valueAt(elapsed time.Duration) float32 {
+	var count = len(a.keys)
+	if count == 0 {
+		return 0
+	}
+	if elapsed <= a.keys[0].Time {
+		return a.keys[0].Value
+	}
+	if elapsed >= a.keys[count-1].Time {
+		return a.keys[count-1].Value
+	}
+	// Find the segment [i, i+1] containing elapsed.
+	var i = sort.Search(count, func(i int) bool {
+		return a.keys[i].Time > elapsed
+	}) - 1
+	var (
+		key  = a.keys[i]
+		next = a.keys[i+1]
+		span = next.Time - key.Time
+		t    = float64(elapsed-key.Time) / float64(span)
+	)
+	switch key.Interpolation {
+	case InterpolateStep:
+		return key.Value
+	case InterpolateEased:
+		if key.Easing != nil {
+			t = key.Easing(t)
+		}
+	case InterpolateHermite:
+		return hermite(key.Value, next.Value, key.OutTangent, next.InTangent, span, t)
+	case InterpolateCatmullRom:
+		return hermite(key.Value, next.Value, a.catmullRomTangent(i), a.catmullRomTangent(i+1), span, t)
+	}
+	return LerpFloat(key.Value, next.Value, t)
+}
+
+// Slope through the neighbouring keys, or towards the only neighbour at the
+// ends of the track.
+func (a *KeyframeTrack) catmullRomTangent(i int) float32 {
+	var (
+		prev = a.keys[max(i-1, 0)]
+		next = a.keys[min(i+1, len(a.keys)-1)]
+		span = (next.Time - prev.Time).Seconds()
+	)
+	if span == 0 {
+		return 0
+	}
+	return float32(float64(next.Value-prev.Value) / span)
+}
+
+// Evaluates a cubic Hermite segment with slopes given in units per second.
+func hermite(p0, p1, m0, m1 float32, span time.Duration, t float64) float32 {
+	var (
+		t2  = t * t
+		t3  = t2 * t
+		h00 = 2*t3 - 3*t2 + 1
+		h10 = t3 - 2*t2 + t
+		h01 = -2*t3 + 3*t2
+		h11 = t3 - t2
+		s   = span.Seconds()
+	)
+	return float32(h00*float64(p0) + h10*s*float64(m0) + h01*float64(p1) + h11*s*float64(m1))
+}
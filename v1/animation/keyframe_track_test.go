@@ -0,0 +1,137 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func msKey(milliseconds int, value float32, interpolation Interpolation) Keyframe {
+	return Keyframe{
+		Time:          time.Duration(milliseconds) * time.Millisecond,
+		Value:         value,
+		Interpolation: interpolation,
+	}
+}
+
+// Tests each interpolation mode at the middle of its segment.
+func TestKeyframeTrackInterpolation(t *testing.T) {
+	var cases = []struct {
+		keys     []Keyframe
+		expected float32
+	}{
+		{[]Keyframe{msKey(0, 0, InterpolateStep), msKey(1000, 10, InterpolateStep)}, 0},
+		{[]Keyframe{msKey(0, 0, InterpolateLinear), msKey(1000, 10, InterpolateLinear)}, 5},
+		{[]Keyframe{{Time: 0, Value: 0, Interpolation: InterpolateEased, Easing: EaseInQuad}, msKey(1000, 10, InterpolateLinear)}, 2.5},
+		{[]Keyframe{{Time: 0, Value: 0, Interpolation: InterpolateHermite, OutTangent: 40}, msKey(1000, 10, InterpolateLinear)}, 10},
+		{[]Keyframe{msKey(0, 0, InterpolateCatmullRom), msKey(1000, 10, InterpolateCatmullRom), msKey(2000, 20, InterpolateLinear)}, 5},
+	}
+	for i, c := range cases {
+		var track = NewKeyframeTrack(c.keys, false, nil)
+		if got := track.ValueAt(500 * time.Millisecond); math.Abs(float64(got-c.expected)) > 1e-5 {
+			t.Errorf("Case %v produced %v, expected %v", i, got, c.expected)
+		}
+	}
+}
+
+// Tests that Catmull-Rom segments are smooth across keys.
+func TestKeyframeTrackCatmullRomSmooth(t *testing.T) {
+	var (
+		keys = []Keyframe{
+			msKey(0, 0, InterpolateCatmullRom),
+			msKey(1000, 10, InterpolateCatmullRom),
+			msKey(2000, 0, InterpolateCatmullRom),
+			msKey(3000, 10, InterpolateLinear),
+		}
+		track  = NewKeyframeTrack(keys, false, nil)
+		before = track.ValueAt(999 * time.Millisecond)
+		at     = track.ValueAt(1000 * time.Millisecond)
+		after  = track.ValueAt(1001 * time.Millisecond)
+	)
+	if at != 10 {
+		t.Fatalf("Track did not pass through key, got %v", at)
+	}
+	if math.Abs(float64((at-before)-(after-at))) > 1e-3 {
+		t.Fatalf("Track is not smooth at key: %v, %v, %v", before, at, after)
+	}
+}
+
+// Tests that a track writes to its target and reports completion.
+func TestKeyframeTrackUpdate(t *testing.T) {
+	var (
+		dest float32
+		done = false
+		keys = []Keyframe{msKey(1000, 10, InterpolateLinear), msKey(0, 0, InterpolateLinear), msKey(2000, 30, InterpolateStep)}
+		anim = NewKeyframeTrack(keys, false, &dest)
+		resp time.Duration
+	)
+	anim.SetCallback(func() { done = true })
+	resp = anim.Update(500 * time.Millisecond)
+	if dest != 5 || resp != 0 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	resp = anim.Update(1000 * time.Millisecond)
+	if dest != 20 || resp != 0 || done {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	resp = anim.Update(700 * time.Millisecond)
+	if dest != 30 || resp != 200*time.Millisecond {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if !anim.IsDone() || !done {
+		t.Fatalf("Track not marked done when finished")
+	}
+}
+
+// Tests that a looping track wraps around and never finishes.
+func TestKeyframeTrackLoop(t *testing.T) {
+	var (
+		dest float32
+		keys = []Keyframe{msKey(0, 0, InterpolateLinear), msKey(1000, 10, InterpolateLinear)}
+		anim = NewKeyframeTrack(keys, true, &dest)
+	)
+	anim.Update(2250 * time.Millisecond)
+	if dest != 2.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if anim.IsDone() {
+		t.Fatalf("Looping track marked done (should not)")
+	}
+	if got := anim.ValueAt(-250 * time.Millisecond); got != 7.5 {
+		t.Fatalf("ValueAt did not wrap negative time, got %v", got)
+	}
+	if anim.Elapsed != 2250*time.Millisecond || dest != 2.5 {
+		t.Fatalf("ValueAt changed the track state")
+	}
+}
+
+// Tests that a reversed track plays from its last key to its first.
+func TestKeyframeTrackReverse(t *testing.T) {
+	var (
+		dest float32
+		keys = []Keyframe{msKey(0, 0, InterpolateLinear), msKey(1000, 10, InterpolateLinear)}
+		anim = NewKeyframeTrack(keys, false, &dest)
+	)
+	anim.SetDirection(Reverse)
+	anim.Update(250 * time.Millisecond)
+	if dest != 7.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if anim.Update(1*time.Second) != 250*time.Millisecond || dest != 0 || !anim.IsDone() {
+		t.Fatalf("Reversed track did not finish at its first key")
+	}
+}
@@ -0,0 +1,585 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Names the targets that animation definitions may refer to.
+type Bindings struct {
+	floats map[string]*float32
+	ints   map[string]*int
+}
+
+func NewBindings() *Bindings {
+	return &Bindings{floats: map[string]*float32{}, ints: map[string]*int{}}
+}
+
+// Binds a name to a target for continuous animations.
+func (b *Bindings) BindFloat(name string, target *float32) *Bindings {
+	b.floats[name] = target
+	return b
+}
+
+// Binds a name to a target for frame animations.
+func (b *Bindings) BindInt(name string, target *int) *Bindings {
+	b.ints[name] = target
+	return b
+}
+
+// Reports a definition which does not match the schema.  Path locates the
+// offending value, for example "$.children[1].function.duration".
+type SchemaError struct {
+	Path    string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Path, e.Message)
+}
+
+// Builds an animation from a JSON definition such as:
+//
+//	{
+//	  "type": "chained",
+//	  "loop": true,
+//	  "children": [
+//	    {"type": "bounded", "duration": "500ms"},
+//	    {"type": "continuous", "target": "x",
+//	     "function": {"name": "linear", "duration": "1s", "from": 0, "to": 10}},
+//	    {"type": "frames", "target": "sprite",
+//	     "frames": [{"duration": 100, "index": 0}, {"duration": 100, "index": 1}]}
+//	  ]
+//	}
+//
+// Durations are strings such as "1.5s", or numbers of milliseconds.
+func LoadAnimation(data []byte, bindings *Bindings) (Animator, error) {
+	var (
+		definition any
+		err        error
+	)
+	if err = json.Unmarshal(data, &definition); err != nil {
+		return nil, err
+	}
+	return BuildAnimation(definition, bindings)
+}
+
+// Builds an animation from a decoded definition made of maps, slices,
+// strings, numbers and booleans.  The package only parses JSON; use this to
+// load definitions from YAML or other formats which decode into the same
+// shapes.
+func BuildAnimation(definition any, bindings *Bindings) (Animator, error) {
+	if bindings == nil {
+		bindings = NewBindings()
+	}
+	return buildAnimation(definition, "$", bindings)
+}
+
+var directionNames = map[string]Direction{
+	"forward":  Forward,
+	"reverse":  Reverse,
+	"pingpong": PingPong,
+}
+
+func buildAnimation(value any, path string, bindings *Bindings) (animator Animator, err error) {
+	var (
+		d    schemaObject
+		kind string
+	)
+	if d, err = newSchemaObject(value, path); err != nil {
+		return nil, err
+	}
+	if kind, err = d.string("type", true); err != nil {
+		return nil, err
+	}
+	switch kind {
+	case "bounded":
+		animator, err = buildBounded(d)
+	case "continuous":
+		animator, err = buildContinuous(d, bindings)
+	case "frames":
+		animator, err = buildFrames(d, bindings)
+	case "chained":
+		animator, err = buildChained(d, bindings)
+	case "grouped":
+		animator, err = buildGrouped(d, bindings)
+	default:
+		return nil, d.errorf("type", "unknown animation type %q", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var direction string
+	if direction, err = d.string("direction", false); err != nil {
+		return nil, err
+	}
+	if direction != "" {
+		var resolved, ok = directionNames[direction]
+		if !ok {
+			return nil, d.errorf("direction", "unknown direction %q", direction)
+		}
+		SetAnimatorDirection(animator, resolved)
+	}
+	return animator, nil
+}
+
+func buildBounded(d schemaObject) (Animator, error) {
+	var (
+		duration time.Duration
+		err      error
+	)
+	if err = d.check("type", "direction", "duration"); err != nil {
+		return nil, err
+	}
+	if duration, err = d.duration("duration", true); err != nil {
+		return nil, err
+	}
+	return NewBoundedAnimation(duration), nil
+}
+
+func buildContinuous(d schemaObject, bindings *Bindings) (Animator, error) {
+	var (
+		target   *float32
+		function ContinuousFunc
+		value    any
+		err      error
+	)
+	if err = d.check("type", "direction", "function", "target"); err != nil {
+		return nil, err
+	}
+	if target, err = bindTarget(d, bindings.floats); err != nil {
+		return nil, err
+	}
+	if value, err = d.require("function"); err != nil {
+		return nil, err
+	}
+	if function, err = buildFunction(value, d.path+".function"); err != nil {
+		return nil, err
+	}
+	return NewContinuousAnimation(function, target), nil
+}
+
+func buildFunction(value any, path string) (ContinuousFunc, error) {
+	var (
+		d        schemaObject
+		name     string
+		duration time.Duration
+		err      error
+	)
+	if d, err = newSchemaObject(value, path); err != nil {
+		return nil, err
+	}
+	if name, err = d.string("name", true); err != nil {
+		return nil, err
+	}
+	switch name {
+	case "linear":
+		err = d.check("name", "duration", "from", "to")
+	case "sine-decay":
+		err = d.check("name", "duration", "amplitude", "frequency", "decay")
+	case "timing":
+		err = d.check("name", "duration", "from", "to", "timing")
+	default:
+		return nil, d.errorf("name", "unknown function %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if duration, err = d.duration("duration", true); err != nil {
+		return nil, err
+	}
+	if name == "sine-decay" {
+		var amplitude, frequency, decay float64
+		if amplitude, err = d.float("amplitude", 1); err != nil {
+			return nil, err
+		}
+		if frequency, err = d.float("frequency", 1); err != nil {
+			return nil, err
+		}
+		if decay, err = d.float("decay", 1); err != nil {
+			return nil, err
+		}
+		return SineDecayFunc(duration, float32(amplitude), float32(frequency), float32(decay)), nil
+	}
+	var from, to float64
+	if from, err = d.float("from", 0); err != nil {
+		return nil, err
+	}
+	if to, err = d.float("to", 1); err != nil {
+		return nil, err
+	}
+	if name == "linear" {
+		return LinearFunc(duration, float32(from), float32(to)), nil
+	}
+	var (
+		spec string
+		ease Easing
+	)
+	if spec, err = d.string("timing", true); err != nil {
+		return nil, err
+	}
+	if ease, err = ParseTimingFunction(spec); err != nil {
+		return nil, d.errorf("timing", "%v", err)
+	}
+	return EasedFunc(duration, float32(from), float32(to), ease), nil
+}
+
+func buildFrames(d schemaObject, bindings *Bindings) (Animator, error) {
+	var (
+		target *int
+		loop   bool
+		values []any
+		frames []Frame
+		marks  [][]FrameEvent
+		err    error
+	)
+	if err = d.check("type", "direction", "frames", "loop", "target"); err != nil {
+		return nil, err
+	}
+	if target, err = bindTarget(d, bindings.ints); err != nil {
+		return nil, err
+	}
+	if loop, err = d.bool("loop"); err != nil {
+		return nil, err
+	}
+	if values, err = d.list("frames"); err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		var (
+			frame  Frame
+			events []FrameEvent
+		)
+		if frame, events, err = buildFrame(value, fmt.Sprintf("%v.frames[%v]", d.path, i)); err != nil {
+			return nil, err
+		}
+		frames = append(frames, frame)
+		marks = append(marks, events)
+	}
+	var anim = NewFrameAnimation(frames, loop, target)
+	for i, events := range marks {
+		for _, event := range events {
+			anim.AddFrameEvent(i, event.Name, event.Payload)
+		}
+	}
+	return anim, nil
+}
+
+func buildFrame(value any, path string) (frame Frame, marks []FrameEvent, err error) {
+	var (
+		d      schemaObject
+		events []any
+	)
+	if d, err = newSchemaObject(value, path); err != nil {
+		return
+	}
+	if err = d.check("duration", "index", "events"); err != nil {
+		return
+	}
+	if frame.Duration, err = d.duration("duration", true); err != nil {
+		return
+	}
+	if frame.Index, err = d.int("index", true); err != nil {
+		return
+	}
+	if _, ok := d.fields["events"]; !ok {
+		return
+	}
+	if events, err = d.list("events"); err != nil {
+		return
+	}
+	for i, value := range events {
+		var (
+			event schemaObject
+			name  string
+		)
+		if event, err = newSchemaObject(value, fmt.Sprintf("%v.events[%v]", path, i)); err != nil {
+			return
+		}
+		if err = event.check("name", "payload"); err != nil {
+			return
+		}
+		if name, err = event.string("name", true); err != nil {
+			return
+		}
+		marks = append(marks, FrameEvent{name, event.fields["payload"]})
+	}
+	return
+}
+
+func buildChained(d schemaObject, bindings *Bindings) (Animator, error) {
+	var (
+		children []Animator
+		loop     bool
+		err      error
+	)
+	if err = d.check("type", "direction", "children", "loop"); err != nil {
+		return nil, err
+	}
+	if loop, err = d.bool("loop"); err != nil {
+		return nil, err
+	}
+	if children, err = buildChildren(d, bindings); err != nil {
+		return nil, err
+	}
+	return NewChainedAnimation(children, loop), nil
+}
+
+func buildGrouped(d schemaObject, bindings *Bindings) (Animator, error) {
+	var (
+		children []Animator
+		err      error
+	)
+	if err = d.check("type", "direction", "children"); err != nil {
+		return nil, err
+	}
+	if children, err = buildChildren(d, bindings); err != nil {
+		return nil, err
+	}
+	return NewGroupedAnimation(children), nil
+}
+
+func buildChildren(d schemaObject, bindings *Bindings) ([]Animator, error) {
+	var (
+		values   []any
+		children []Animator
+		err      error
+	)
+	if values, err = d.list("children"); err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		var child Animator
+		if child, err = buildAnimation(value, fmt.Sprintf("%v.children[%v]", d.path, i), bindings); err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+// Looks up the optional target of a definition.
+func bindTarget[T any](d schemaObject, targets map[string]*T) (*T, error) {
+	var (
+		name string
+		err  error
+	)
+	if name, err = d.string("target", false); err != nil || name == "" {
+		return nil, err
+	}
+	var target, ok = targets[name]
+	if !ok {
+		return nil, d.errorf("target", "unbound target %q", name)
+	}
+	return target, nil
+}
+
+// An object within a definition, along with where it was found.
+type schemaObject struct {
+	path   string
+	fields map[string]any
+}
+
+func newSchemaObject(value any, path string) (schemaObject, error) {
+	switch fields := value.(type) {
+	case map[string]any:
+		return schemaObject{path, fields}, nil
+	case map[any]any:
+		// Some YAML decoders produce maps with interface keys.
+		var converted = map[string]any{}
+		for key, field := range fields {
+			var name, ok = key.(string)
+			if !ok {
+				return schemaObject{}, &SchemaError{path, fmt.Sprintf("key %v is not a string", key)}
+			}
+			converted[name] = field
+		}
+		return schemaObject{path, converted}, nil
+	}
+	return schemaObject{}, &SchemaError{path, fmt.Sprintf("expected an object, got %v", describe(value))}
+}
+
+func (d schemaObject) errorf(key string, format string, args ...any) error {
+	return &SchemaError{d.path + "." + key, fmt.Sprintf(format, args...)}
+}
+
+// Rejects fields the schema does not know, which are usually typos.
+func (d schemaObject) check(allowed ...string) error {
+	var unknown []string
+	for key := range d.fields {
+		if !slices.Contains(allowed, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	return d.errorf(unknown[0], "unknown field")
+}
+
+func (d schemaObject) require(key string) (any, error) {
+	var value, ok = d.fields[key]
+	if !ok {
+		return nil, &SchemaError{d.path, fmt.Sprintf("missing field %q", key)}
+	}
+	return value, nil
+}
+
+func (d schemaObject) string(key string, required bool) (string, error) {
+	var value, ok = d.fields[key]
+	if !ok {
+		if required {
+			return "", &SchemaError{d.path, fmt.Sprintf("missing field %q", key)}
+		}
+		return "", nil
+	}
+	var text, isString = value.(string)
+	if !isString {
+		return "", d.errorf(key, "expected a string, got %v", describe(value))
+	}
+	return text, nil
+}
+
+func (d schemaObject) bool(key string) (bool, error) {
+	var value, ok = d.fields[key]
+	if !ok {
+		return false, nil
+	}
+	var flag, isBool = value.(bool)
+	if !isBool {
+		return false, d.errorf(key, "expected a boolean, got %v", describe(value))
+	}
+	return flag, nil
+}
+
+func (d schemaObject) float(key string, fallback float64) (float64, error) {
+	var value, ok = d.fields[key]
+	if !ok {
+		return fallback, nil
+	}
+	var number, isNumber = schemaNumber(value)
+	if !isNumber {
+		return 0, d.errorf(key, "expected a number, got %v", describe(value))
+	}
+	return number, nil
+}
+
+func (d schemaObject) int(key string, required bool) (int, error) {
+	var (
+		number float64
+		err    error
+	)
+	if _, ok := d.fields[key]; !ok && required {
+		return 0, &SchemaError{d.path, fmt.Sprintf("missing field %q", key)}
+	}
+	if number, err = d.float(key, 0); err != nil {
+		return 0, err
+	}
+	if number != math.Trunc(number) {
+		return 0, d.errorf(key, "expected an integer, got %v", number)
+	}
+	return int(number), nil
+}
+
+// Reads a duration given as a string such as "1.5s", or as a number of
+// milliseconds.
+func (d schemaObject) duration(key string, required bool) (time.Duration, error) {
+	var value, ok = d.fields[key]
+	if !ok {
+		if required {
+			return 0, &SchemaError{d.path, fmt.Sprintf("missing field %q", key)}
+		}
+		return 0, nil
+	}
+	var (
+		duration time.Duration
+		err      error
+	)
+	if text, isString := value.(string); isString {
+		if duration, err = time.ParseDuration(text); err != nil {
+			return 0, d.errorf(key, "invalid duration %q", text)
+		}
+	} else if number, isNumber := schemaNumber(value); isNumber {
+		var nanoseconds = number * float64(time.Millisecond)
+		if math.Abs(nanoseconds) >= math.MaxInt64 {
+			return 0, d.errorf(key, "duration %vms is out of range", number)
+		}
+		duration = time.Duration(nanoseconds)
+	} else {
+		return 0, d.errorf(key, "expected a duration, got %v", describe(value))
+	}
+	if duration < 0 {
+		return 0, d.errorf(key, "duration %v is negative", duration)
+	}
+	return duration, nil
+}
+
+func (d schemaObject) list(key string) ([]any, error) {
+	var value, ok = d.fields[key]
+	if !ok {
+		return nil, &SchemaError{d.path, fmt.Sprintf("missing field %q", key)}
+	}
+	var items, isList = value.([]any)
+	if !isList {
+		return nil, d.errorf(key, "expected a list, got %v", describe(value))
+	}
+	return items, nil
+}
+
+// Accepts the number types produced by the common JSON and YAML decoders.
+func schemaNumber(value any) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	case json.Number:
+		var parsed, err = number.Float64()
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+func describe(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case []any:
+		return "a list"
+	case map[string]any, map[any]any:
+		return "an object"
+	}
+	if _, ok := schemaNumber(value); ok {
+		return "a number"
+	}
+	return fmt.Sprintf("%T", value)
+}
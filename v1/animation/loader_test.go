@@ -0,0 +1,173 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+const loaderDefinition = `{
+	"type": "chained",
+	"children": [
+		{"type": "bounded", "duration": "500ms"},
+		{"type": "grouped", "children": [
+			{"type": "continuous", "target": "x",
+			 "function": {"name": "linear", "duration": "1s", "from": 0, "to": 10}},
+			{"type": "frames", "target": "sprite", "direction": "reverse", "frames": [
+				{"duration": 250, "index": 3},
+				{"duration": 250, "index": 4, "events": [{"name": "step", "payload": "left"}]}
+			]}
+		]}
+	]
+}`
+
+func TestLoadAnimation(t *testing.T) {
+	var (
+		x        float32
+		sprite   int
+		bindings = NewBindings().BindFloat("x", &x).BindInt("sprite", &sprite)
+		anim     Animator
+		err      error
+	)
+	if anim, err = LoadAnimation([]byte(loaderDefinition), bindings); err != nil {
+		t.Fatalf("Could not load animation: %v", err)
+	}
+	if LengthOf(anim) != 1500*time.Millisecond {
+		t.Fatalf("Loaded animation should be 1.5s long, got %v", LengthOf(anim))
+	}
+	anim.Update(600 * time.Millisecond)
+	if x != 1 || sprite != 4 {
+		t.Fatalf("Targets were not bound, got x=%v sprite=%v", x, sprite)
+	}
+	anim.Update(500 * time.Millisecond)
+	if x != 6 || sprite != 3 {
+		t.Fatalf("Reversed frames should end on index 3, got x=%v sprite=%v", x, sprite)
+	}
+	if anim.Update(500*time.Millisecond) != 100*time.Millisecond || !anim.IsDone() {
+		t.Fatalf("Loaded animation should be done")
+	}
+}
+
+func TestBuildAnimation(t *testing.T) {
+	var (
+		x          float32
+		definition = map[string]any{
+			"type": "continuous",
+			"function": map[any]any{
+				"name":     "timing",
+				"duration": 1000,
+				"from":     0,
+				"to":       1,
+				"timing":   "steps(2, jump-end)",
+			},
+			"target": "x",
+		}
+		anim Animator
+		err  error
+	)
+	if anim, err = BuildAnimation(definition, NewBindings().BindFloat("x", &x)); err != nil {
+		t.Fatalf("Could not build animation: %v", err)
+	}
+	anim.Update(600 * time.Millisecond)
+	if x != 0.5 {
+		t.Fatalf("Timing function was not applied, got %v", x)
+	}
+}
+
+// Tests a definition shaped like the output of a YAML decoder, with
+// interface keyed maps and integer numbers.
+func TestBuildAnimationYAML(t *testing.T) {
+	var (
+		x          float32
+		sprite     int
+		bindings   = NewBindings().BindFloat("x", &x).BindInt("sprite", &sprite)
+		definition = map[any]any{
+			"type": "grouped",
+			"children": []any{
+				map[any]any{
+					"type":   "continuous",
+					"target": "x",
+					"function": map[any]any{
+						"name":     "linear",
+						"duration": "1s",
+						"from":     0,
+						"to":       int64(10),
+					},
+				},
+				map[any]any{
+					"type":   "frames",
+					"target": "sprite",
+					"frames": []any{
+						map[any]any{"duration": 500, "index": 3},
+						map[any]any{"duration": uint64(500), "index": 4, "events": []any{
+							map[any]any{"name": "step", "payload": map[any]any{"foot": "left"}},
+						}},
+					},
+				},
+			},
+		}
+		anim Animator
+		err  error
+	)
+	if anim, err = BuildAnimation(definition, bindings); err != nil {
+		t.Fatalf("Could not build animation: %v", err)
+	}
+	anim.Update(600 * time.Millisecond)
+	if x != 6 || sprite != 4 {
+		t.Fatalf("Targets were not bound, got x=%v sprite=%v", x, sprite)
+	}
+	definition["children"].([]any)[0].(map[any]any)[1] = "one"
+	if _, err = BuildAnimation(definition, bindings); err == nil {
+		t.Fatalf("Expected an error for a key which is not a string")
+	}
+}
+
+func TestLoadAnimationSchemaErrors(t *testing.T) {
+	var tests = []struct {
+		definition string
+		path       string
+		message    string
+	}{
+		{`[]`, "$", "expected an object, got a list"},
+		{`{"duration": "1s"}`, "$", `missing field "type"`},
+		{`{"type": "wobble"}`, "$.type", `unknown animation type "wobble"`},
+		{`{"type": "bounded", "duration": "soon"}`, "$.duration", `invalid duration "soon"`},
+		{`{"type": "bounded", "duration": 1e13}`, "$.duration", "duration 1e+13ms is out of range"},
+		{`{"type": "bounded", "duration": -1e13}`, "$.duration", "duration -1e+13ms is out of range"},
+		{`{"type": "bounded", "duration": "1s", "lenght": 1}`, "$.lenght", "unknown field"},
+		{`{"type": "bounded", "duration": "1s", "direction": "up"}`, "$.direction", `unknown direction "up"`},
+		{`{"type": "chained", "children": [{"type": "bounded"}]}`, "$.children[0]", `missing field "duration"`},
+		{`{"type": "grouped", "children": {}}`, "$.children", "expected a list, got an object"},
+		{`{"type": "continuous", "target": "y", "function": {"name": "linear", "duration": 1}}`, "$.target", `unbound target "y"`},
+		{`{"type": "continuous", "function": {"name": "linear", "duration": 1, "to": "10"}}`, "$.function.to", "expected a number, got a string"},
+		{`{"type": "continuous", "function": {"name": "timing", "duration": 1, "timing": "fast"}}`, "$.function.timing", `unknown timing function "fast"`},
+		{`{"type": "frames", "frames": [{"duration": 1, "index": 0}, {"duration": 1, "index": 1.5}]}`, "$.frames[1].index", "expected an integer, got 1.5"},
+		{`{"type": "frames", "loop": 1, "frames": []}`, "$.loop", "expected a boolean, got a number"},
+	}
+	for _, test := range tests {
+		var (
+			schemaErr *SchemaError
+			_, err    = LoadAnimation([]byte(test.definition), nil)
+		)
+		if !errors.As(err, &schemaErr) {
+			t.Fatalf("Expected a schema error for %v, got %v", test.definition, err)
+		}
+		if schemaErr.Path != test.path || schemaErr.Message != test.message {
+			t.Fatalf("Expected %v: %v, got %v", test.path, test.message, err)
+		}
+	}
+}
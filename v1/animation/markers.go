@@ -0,0 +1,140 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"sort"
+	"time"
+)
+
+type marker struct {
+	name     string
+	offset   time.Duration
+	callback AnimatorCallback
+}
+
+type markerCrossing struct {
+	at       time.Duration
+	listener *listener[marker]
+}
+
+// Callbacks at offsets into an animation.  Each marker is called once as the
+// playhead crosses it, so it is called again only after a Reset or after
+// moving back past it.
+type markerSet struct {
+	listeners []*listener[marker]
+	fired     time.Duration
+}
+
+// Creates the markers of an animation on first use.  Markers behind the
+// playhead wait for the next pass.
+func ensureMarkers(markers **markerSet, travel time.Duration) *markerSet {
+	if *markers == nil {
+		*markers = &markerSet{}
+		(*markers).reset()
+		if travel > 0 {
+			(*markers).fired = travel
+		}
+	}
+	return *markers
+}
+
+func (m *markerSet) add(name string, offset time.Duration, callback AnimatorCallback) Subscription {
+	return subscribe(&m.listeners, marker{name, offset, callback})
+}
+
+// Returns the offset of the first marker with a name.
+func (m *markerSet) find(name string) (time.Duration, bool) {
+	if m != nil {
+		for _, l := range m.listeners {
+			if l.callback.name == name {
+				return l.callback.offset, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Calls the markers crossed since they were last called, in the order they
+// were crossed.  Length is the length of one leg of the animation, or 0 if
+// it has no end.  Ping-pong animations cross each marker once on the way out
+// and once on the way back.
+func (m *markerSet) play(travel, length time.Duration, direction Direction) {
+	if m == nil {
+		return
+	}
+	var (
+		from    = m.fired
+		to      = travel
+		crossed []markerCrossing
+	)
+	m.fired = travel
+	if to <= from {
+		return
+	}
+	if length > 0 {
+		to = min(to, passLength(length, direction))
+	}
+	for _, l := range m.listeners {
+		for _, t := range markerTravels(l.callback.offset, length, direction) {
+			if t > from && t <= to {
+				crossed = append(crossed, markerCrossing{t, l})
+			}
+		}
+	}
+	sort.SliceStable(crossed, func(i, j int) bool {
+		return crossed[i].at < crossed[j].at
+	})
+	for _, c := range crossed {
+		if !c.listener.removed {
+			c.listener.callback.callback()
+		}
+	}
+}
+
+// Returns when a marker is crossed, measured in time travelled.
+func markerTravels(offset, length time.Duration, direction Direction) []time.Duration {
+	if offset < 0 || (length > 0 && offset > length) {
+		return nil
+	}
+	switch {
+	case length <= 0:
+		return []time.Duration{offset}
+	case direction == Reverse:
+		return []time.Duration{length - offset}
+	case direction == PingPong && offset < length:
+		return []time.Duration{offset, 2*length - offset}
+	}
+	return []time.Duration{offset}
+}
+
+// Moves the markers to a position after seeking.  Markers are only called
+// when replaying.
+func (m *markerSet) seek(travel, length time.Duration, direction Direction, mode SeekMode) {
+	if m == nil {
+		return
+	}
+	if mode == SeekReplay {
+		m.play(travel, length, direction)
+	} else {
+		m.fired = travel
+	}
+}
+
+func (m *markerSet) reset() {
+	if m != nil {
+		m.fired = -1
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+// Implemented by animators which can be paused while their parent keeps
+// updating them.  While paused, Update does not advance the animator and uses
+// up all of the elapsed time, so a parent such as ChainedAnimation waits
+// rather than moving on to the next child.  An animator which finished
+// before it was paused stays done and returns all of the elapsed time, so it
+// holds nothing up.  Seeking still works while paused, and may finish the
+// animator.  TimeScaled, GroupedAnimation and ChainedAnimation are Pausable,
+// and pausing one keeps its time scale for when it resumes.
+type Pausable interface {
+	Pause()
+	Resume()
+	IsPaused() bool
+}
+
+// Makes any animator Pausable.  It is a TimeScaled at a scale of 1.
+type PausableAnimation = TimeScaled
+
+func NewPausableAnimation(animator Animator) *PausableAnimation {
+	return NewTimeScaled(animator, 1)
+}
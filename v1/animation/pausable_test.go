@@ -0,0 +1,111 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests that a paused child holds up its group while its sibling runs.
+func TestPausableAnimationInGroup(t *testing.T) {
+	var (
+		done   = false
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(1 * time.Second)
+		paused = NewPausableAnimation(child2)
+		anim   = NewGroupedAnimation([]Animator{child1, paused})
+	)
+	anim.SetCallback(func() { done = true })
+	anim.Update(500 * time.Millisecond)
+	paused.Pause()
+	if anim.Update(1*time.Second) != 0 || anim.IsDone() || done {
+		t.Fatalf("GroupedAnimation finished while a child was paused")
+	}
+	if !child1.IsDone() || child2.Elapsed != 500*time.Millisecond {
+		t.Fatalf("Paused child advanced, got %v", child2.Elapsed)
+	}
+	paused.Resume()
+	if anim.Update(750*time.Millisecond) != 250*time.Millisecond || !done {
+		t.Fatalf("GroupedAnimation did not finish after resuming")
+	}
+}
+
+// Tests that a chain waits on a paused child instead of moving on.
+func TestPausableAnimationInChain(t *testing.T) {
+	var (
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(1 * time.Second)
+		paused = NewPausableAnimation(child1)
+		anim   = NewChainedAnimation([]Animator{paused, child2}, false)
+	)
+	paused.Pause()
+	if anim.Update(5*time.Second) != 0 || child2.Elapsed != 0 {
+		t.Fatalf("ChainedAnimation moved past a paused child")
+	}
+	paused.Resume()
+	anim.Update(1500 * time.Millisecond)
+	if child2.Elapsed != 500*time.Millisecond {
+		t.Fatalf("ChainedAnimation did not carry time past a resumed child")
+	}
+}
+
+// Tests that pausing a finished animator does not hold up its parent.
+func TestPausableAnimationDone(t *testing.T) {
+	var (
+		child = NewBoundedAnimation(1 * time.Second)
+		anim  = NewPausableAnimation(child)
+	)
+	anim.Update(1 * time.Second)
+	anim.Pause()
+	if !anim.IsDone() || anim.Update(300*time.Millisecond) != 300*time.Millisecond {
+		t.Fatalf("Paused finished animator did not pass time through")
+	}
+	anim.Reset()
+	if !anim.IsPaused() || anim.IsDone() || anim.Update(300*time.Millisecond) != 0 {
+		t.Fatalf("Reset paused animator did not stay paused")
+	}
+	anim.Seek(400*time.Millisecond, SeekSilent)
+	if child.Elapsed != 400*time.Millisecond {
+		t.Fatalf("Could not seek a paused animator, got %v", child.Elapsed)
+	}
+}
+
+// Tests that built-in containers pause without being wrapped.
+func TestPausableContainers(t *testing.T) {
+	var (
+		child1          = NewBoundedAnimation(1 * time.Second)
+		child2          = NewBoundedAnimation(1 * time.Second)
+		inner           = NewGroupedAnimation([]Animator{child2})
+		anim            = NewChainedAnimation([]Animator{child1, inner}, false)
+		_      Pausable = anim
+	)
+	inner.SetTimeScale(2)
+	inner.Pause()
+	anim.Update(1500 * time.Millisecond)
+	if !child1.IsDone() || child2.Elapsed != 0 || !inner.IsPaused() {
+		t.Fatalf("Paused GroupedAnimation advanced its child, got %v", child2.Elapsed)
+	}
+	inner.Resume()
+	anim.Update(250 * time.Millisecond)
+	if child2.Elapsed != 500*time.Millisecond || inner.TimeScale() != 2 {
+		t.Fatalf("Resumed GroupedAnimation lost its time scale, got %v", child2.Elapsed)
+	}
+	anim.Pause()
+	anim.Seek(1750*time.Millisecond, SeekSilent)
+	if !anim.IsDone() || anim.Update(100*time.Millisecond) != 100*time.Millisecond {
+		t.Fatalf("Paused ChainedAnimation should finish when sought to its end")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+)
+
+// A rotation quaternion.
+type Quat struct {
+	W, X, Y, Z float32
+}
+
+func QuatIdentity() Quat {
+	return Quat{1, 0, 0, 0}
+}
+
+// Builds a rotation of the given angle in radians about an axis.
+func QuatFromAxisAngle(axis Vec3, radians float32) Quat {
+	var length = math.Sqrt(float64(axis.X*axis.X + axis.Y*axis.Y + axis.Z*axis.Z))
+	if length == 0 {
+		return QuatIdentity()
+	}
+	var (
+		half  = float64(radians) / 2
+		scale = float32(math.Sin(half) / length)
+	)
+	return Quat{float32(math.Cos(half)), axis.X * scale, axis.Y * scale, axis.Z * scale}
+}
+
+func (q Quat) Dot(o Quat) float32 {
+	return q.W*o.W + q.X*o.X + q.Y*o.Y + q.Z*o.Z
+}
+
+func (q Quat) Normalize() Quat {
+	var length = float32(math.Sqrt(float64(q.Dot(q))))
+	if length == 0 {
+		return QuatIdentity()
+	}
+	return Quat{q.W / length, q.X / length, q.Y / length, q.Z / length}
+}
+
+func (q Quat) negate() Quat {
+	return Quat{-q.W, -q.X, -q.Y, -q.Z}
+}
+
+// Spherical interpolation along the shorter arc, at constant angular speed.
+func SlerpQuat(a, b Quat, t float64) Quat {
+	var dot = float64(a.Dot(b))
+	if dot < 0 {
+		b = b.negate()
+		dot = -dot
+	}
+	// Nearly parallel rotations are indistinguishable from nlerp and would
+	// divide by a vanishing sine.
+	if dot > 0.9995 {
+		return NlerpQuat(a, b, t)
+	}
+	var (
+		theta = math.Acos(dot)
+		sin   = math.Sin(theta)
+		wa    = float32(math.Sin((1-t)*theta) / sin)
+		wb    = float32(math.Sin(t*theta) / sin)
+	)
+	return Quat{
+		a.W*wa + b.W*wb,
+		a.X*wa + b.X*wb,
+		a.Y*wa + b.Y*wb,
+		a.Z*wa + b.Z*wb,
+	}.Normalize()
+}
+
+// Normalized linear interpolation along the shorter arc.  Cheaper than
+// SlerpQuat, but the angular speed is not constant.
+func NlerpQuat(a, b Quat, t float64) Quat {
+	if a.Dot(b) < 0 {
+		b = b.negate()
+	}
+	return Quat{
+		LerpFloat(a.W, b.W, t),
+		LerpFloat(a.X, b.X, t),
+		LerpFloat(a.Y, b.Y, t),
+		LerpFloat(a.Z, b.Z, t),
+	}.Normalize()
+}
+
+// Animates a rotation using SlerpQuat or NlerpQuat.
+func NewQuatAnimation(f ContinuousFunc, from, to Quat, lerp Lerp[Quat], target *Quat) *Tween[Quat] {
+	return NewTween(f, from, to, lerp, target)
+}
@@ -0,0 +1,47 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+)
+
+func quatNear(a, b Quat) bool {
+	// Opposite quaternions describe the same rotation.
+	return math.Abs(math.Abs(float64(a.Dot(b)))-1) < 1e-5
+}
+
+// Tests that slerp and nlerp pass through the halfway rotation.
+func TestQuatInterpolation(t *testing.T) {
+	var (
+		axis     = Vec3{0, 0, 1}
+		from     = QuatIdentity()
+		to       = QuatFromAxisAngle(axis, math.Pi/2)
+		expected = QuatFromAxisAngle(axis, math.Pi/4)
+	)
+	for _, lerp := range []Lerp[Quat]{SlerpQuat, NlerpQuat} {
+		if got := lerp(from, to, 0.5); !quatNear(got, expected) {
+			t.Errorf("Quaternion midpoint was %v, expected %v", got, expected)
+		}
+		if got := lerp(from, to.negate(), 0.5); !quatNear(got, expected) {
+			t.Errorf("Quaternion interpolation took the long way, got %v", got)
+		}
+	}
+	var got = SlerpQuat(from, to, 0.25)
+	if !quatNear(got, QuatFromAxisAngle(axis, math.Pi/8)) {
+		t.Errorf("Slerp did not rotate at constant speed, got %v", got)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"time"
+)
+
+// Passed as a count to repeat without end.
+const RepeatForever = 0
+
+// Plays an animator a number of times, optionally waiting between
+// iterations and alternating direction.  Leftover time from one iteration
+// carries into the next, as it does between the children of a
+// ChainedAnimation.
+type RepeatAnimation struct {
+	animator  Animator
+	count     int
+	delay     time.Duration
+	yoyo      bool
+	iteration int
+	waiting   time.Duration
+	events    *Events
+	done      bool
+	direction Direction
+}
+
+// Plays an animator count times, or forever if count is RepeatForever.
+func Repeat(animator Animator, count int) *RepeatAnimation {
+	return RepeatDelay(animator, count, 0)
+}
+
+// Plays an animator count times, waiting between iterations.
+func RepeatDelay(animator Animator, count int, delay time.Duration) *RepeatAnimation {
+	var a = &RepeatAnimation{
+		animator:  animator,
+		count:     max(count, RepeatForever),
+		delay:     max(delay, 0),
+		events:    &Events{},
+		direction: Forward,
+	}
+	if d, ok := animator.(Directional); ok {
+		a.direction = d.Direction()
+	}
+	return a
+}
+
+// Plays an animator forwards and then backwards.  Repeat the result for
+// more round trips.  Animators which are not Directional just play twice.
+func Yoyo(animator Animator) *RepeatAnimation {
+	var a = Repeat(animator, 2)
+	a.yoyo = true
+	a.orient()
+	return a
+}
+
+// Returns the wrapped animator.
+func (a *RepeatAnimation) Animator() Animator {
+	return a.animator
+}
+
+// Returns the current iteration, counting from 0.  While waiting between
+// iterations this is the iteration about to start.
+func (a *RepeatAnimation) Iteration() int {
+	return a.iteration
+}
+
+func (a *RepeatAnimation) SetCallback(callback AnimatorCallback) {
+	a.Events().setCallback(callback)
+}
+
+// Loop events are raised as each iteration after the first begins.
+func (a *RepeatAnimation) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+func (a *RepeatAnimation) IsDone() bool {
+	return a.done
+}
+
+// Negative elapsed time moves the animation back towards its start.  It is
+// ignored if the wrapped animator is not a Measurable Seeker.
+func (a *RepeatAnimation) Update(elapsed time.Duration) time.Duration {
+	var remainder = a.advance(elapsed)
+	a.events.update(a.done)
+	return remainder
+}
+
+func (a *RepeatAnimation) advance(elapsed time.Duration) time.Duration {
+	if elapsed < 0 {
+		if a.canRewind() {
+			a.Seek(max(a.Position()+elapsed, 0), SeekSilent)
+		}
+		return 0
+	}
+	for elapsed > 0 && !a.done {
+		if a.waiting > 0 {
+			var wait = min(elapsed, a.waiting)
+			a.waiting -= wait
+			elapsed -= wait
+			continue
+		}
+		var before = elapsed
+		elapsed = a.animator.Update(elapsed)
+		if !a.animator.IsDone() {
+			break
+		}
+		if a.count != RepeatForever && a.iteration+1 >= a.count {
+			a.done = true
+			break
+		}
+		if elapsed >= before && a.delay == 0 && a.count == RepeatForever {
+			// The iteration took no time, so repeating it would never end.
+			return 0
+		}
+		a.start(a.iteration+1, a.delay)
+		a.events.loop()
+	}
+	return elapsed
+}
+
+// Begins an iteration, after waiting for a delay.
+func (a *RepeatAnimation) start(iteration int, delay time.Duration) {
+	a.enter(iteration, delay)
+	a.animator.Reset()
+}
+
+// Moves to an iteration without resetting the animator, which the caller
+// positions instead.
+func (a *RepeatAnimation) enter(iteration int, delay time.Duration) {
+	a.iteration = iteration
+	a.waiting = delay
+	a.orient()
+}
+
+// Points the animator the way the current iteration plays.
+func (a *RepeatAnimation) orient() {
+	var direction = a.direction
+	if a.yoyo && a.iteration%2 == 1 {
+		switch direction {
+		case Forward:
+			direction = Reverse
+		case Reverse:
+			direction = Forward
+		}
+	}
+	SetAnimatorDirection(a.animator, direction)
+}
+
+func (a *RepeatAnimation) canRewind() bool {
+	return canRewind(a.animator)
+}
+
+func (a *RepeatAnimation) Reset() {
+	a.done = false
+	a.start(0, 0)
+	a.events.reset()
+}
+
+func (a *RepeatAnimation) Delete() {
+	a.events.delete()
+	a.animator.Delete()
+}
+
+// Seeking past the first iteration needs a Measurable animator; otherwise
+// the animation is replayed from the start.
+func (a *RepeatAnimation) Seek(position time.Duration, mode SeekMode) time.Duration {
+	var (
+		length = LengthOf(a.animator)
+		period = length + a.delay
+		total  = a.Length()
+	)
+	position = max(position, 0)
+	a.done = false
+	if length <= 0 {
+		a.start(0, 0)
+		var remainder = a.advance(position)
+		a.events.seek(a.done, mode)
+		return remainder
+	}
+	if a.count != RepeatForever && position >= total {
+		a.enter(a.count-1, 0)
+		SeekAnimator(a.animator, length, SeekSilent)
+		a.done = true
+		a.events.seek(true, mode)
+		return position - total
+	}
+	var (
+		iteration = int(position / period)
+		local     = position - time.Duration(iteration)*period
+	)
+	if local >= length {
+		a.enter(iteration+1, period-local)
+		SeekAnimator(a.animator, 0, SeekSilent)
+	} else {
+		a.enter(iteration, 0)
+		SeekAnimator(a.animator, local, mode)
+	}
+	a.events.seek(false, mode)
+	return 0
+}
+
+func (a *RepeatAnimation) Position() time.Duration {
+	if a.done {
+		return a.Length()
+	}
+	var start = time.Duration(a.iteration) * (LengthOf(a.animator) + a.delay)
+	if a.waiting > 0 {
+		return start - a.waiting
+	}
+	return start + PositionOf(a.animator)
+}
+
+// Returns 0 when repeating forever.
+func (a *RepeatAnimation) Length() time.Duration {
+	if a.count == RepeatForever {
+		return 0
+	}
+	var count = time.Duration(a.count)
+	return count*LengthOf(a.animator) + (count-1)*a.delay
+}
+
+// Sets the direction of the first iteration.  With Yoyo, later iterations
+// alternate from there.
+func (a *RepeatAnimation) SetDirection(direction Direction) {
+	a.direction = direction
+	a.orient()
+}
+
+func (a *RepeatAnimation) Direction() Direction {
+	return a.direction
+}
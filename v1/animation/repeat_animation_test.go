@@ -0,0 +1,116 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRepeat(t *testing.T) {
+	var (
+		done  = false
+		plays = 0
+		child = NewBoundedAnimation(1 * time.Second)
+		anim  = Repeat(child, 3)
+		resp  time.Duration
+	)
+	child.SetCallback(func() { plays++ })
+	anim.SetCallback(func() { done = true })
+	resp = anim.Update(2500 * time.Millisecond)
+	if resp != 0 || anim.Iteration() != 2 || child.Elapsed != 500*time.Millisecond {
+		t.Fatalf("Repeat did not carry time into the next iteration")
+	}
+	if anim.Position() != 2500*time.Millisecond || anim.Length() != 3*time.Second {
+		t.Fatalf("Repeat position was %v", anim.Position())
+	}
+	resp = anim.Update(700 * time.Millisecond)
+	if resp != 200*time.Millisecond || !done || plays != 3 || !anim.IsDone() {
+		t.Fatalf("Repeat did not finish after three iterations, got %v", resp)
+	}
+}
+
+func TestRepeatForever(t *testing.T) {
+	var (
+		child = NewBoundedAnimation(1 * time.Second)
+		anim  = Repeat(child, RepeatForever)
+	)
+	anim.Update(100 * time.Second)
+	if anim.IsDone() || anim.Iteration() != 100 || anim.Length() != 0 {
+		t.Fatalf("Repeat forever stopped at iteration %v", anim.Iteration())
+	}
+	var empty = Repeat(NewBoundedAnimation(0), RepeatForever)
+	if empty.Update(1*time.Second) != 0 {
+		t.Fatalf("Repeating an empty animation forever did not stop")
+	}
+}
+
+func TestRepeatEmpty(t *testing.T) {
+	var anim = Repeat(NewBoundedAnimation(0), 3)
+	if anim.Update(1*time.Second) != 1*time.Second || !anim.IsDone() || anim.Iteration() != 2 {
+		t.Fatalf("Repeating an empty animation should finish at once, got iteration %v", anim.Iteration())
+	}
+}
+
+func TestRepeatDelay(t *testing.T) {
+	var (
+		child = NewBoundedAnimation(1 * time.Second)
+		anim  = RepeatDelay(child, 2, 500*time.Millisecond)
+	)
+	if anim.Length() != 2500*time.Millisecond {
+		t.Fatalf("RepeatDelay length was %v", anim.Length())
+	}
+	anim.Update(1200 * time.Millisecond)
+	if anim.Iteration() != 1 || child.Elapsed != 0 || anim.Position() != 1200*time.Millisecond {
+		t.Fatalf("RepeatDelay did not wait between iterations")
+	}
+	anim.Update(400 * time.Millisecond)
+	if child.Elapsed != 100*time.Millisecond {
+		t.Fatalf("RepeatDelay did not carry time past the wait, got %v", child.Elapsed)
+	}
+	if anim.Update(1*time.Second) != 100*time.Millisecond || !anim.IsDone() {
+		t.Fatalf("RepeatDelay did not finish")
+	}
+	anim.Update(-1100 * time.Millisecond)
+	if anim.Iteration() != 1 || anim.Position() != 1400*time.Millisecond {
+		t.Fatalf("Negative update did not rewind, got %v", anim.Position())
+	}
+}
+
+func TestYoyo(t *testing.T) {
+	var (
+		dest  float32
+		child = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest)
+		anim  = Repeat(Yoyo(child), 2)
+	)
+	if anim.Length() != 4*time.Second {
+		t.Fatalf("Yoyo length was %v", anim.Length())
+	}
+	anim.Update(1250 * time.Millisecond)
+	if dest != 7.5 || child.Direction() != Reverse {
+		t.Fatalf("Yoyo did not play backwards, got %v", dest)
+	}
+	anim.Update(1 * time.Second)
+	if dest != 2.5 || child.Direction() != Forward || anim.Iteration() != 1 {
+		t.Fatalf("Repeated yoyo did not start over, got %v", dest)
+	}
+	anim.Seek(3500*time.Millisecond, SeekSilent)
+	if dest != 5 || child.Direction() != Reverse {
+		t.Fatalf("Yoyo did not seek, got %v", dest)
+	}
+	if anim.Update(1*time.Second) != 500*time.Millisecond || dest != 0 {
+		t.Fatalf("Yoyo did not finish where it started")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Updates an animation from a clock at a fixed rate, for programs without a
+// game loop of their own.  The animation is updated on the goroutine calling
+// Run, so other goroutines must not touch it while it runs.  A Runner runs
+// only once.
+type Runner struct {
+	clock    Clock
+	interval time.Duration
+	update   func(elapsed time.Duration) bool
+	done     chan struct{}
+	once     sync.Once
+	err      error
+}
+
+// Runs an animator until it is done.
+func NewRunner(animator Animator, interval time.Duration, clock Clock) *Runner {
+	return newRunner(interval, clock, func(elapsed time.Duration) bool {
+		animator.Update(elapsed)
+		return animator.IsDone()
+	})
+}
+
+// Runs a manager until it has no animators left.
+func NewManagerRunner(manager *AnimationManager, interval time.Duration, clock Clock) *Runner {
+	return newRunner(interval, clock, func(elapsed time.Duration) bool {
+		manager.Update(elapsed)
+		return manager.Len() == 0
+	})
+}
+
+func newRunner(interval time.Duration, clock Clock, update func(time.Duration) bool) *Runner {
+	if clock == nil {
+		clock = SystemClock
+	}
+	var r = &Runner{
+		clock:    clock,
+		interval: interval,
+		update:   update,
+		done:     make(chan struct{}),
+	}
+	if interval <= 0 {
+		r.err = fmt.Errorf("runner interval %v is not positive", interval)
+	}
+	return r
+}
+
+// Updates the animation on each tick, with the time since the last one,
+// until it is done or the context is cancelled.  Returns the context's error
+// if it was cancelled.  Fails at once if the interval is not positive, and
+// if the runner has already run, without changing Err.
+func (r *Runner) Run(ctx context.Context) error {
+	var first bool
+	r.once.Do(func() { first = true })
+	if !first {
+		return fmt.Errorf("runner has already run")
+	}
+	defer close(r.done)
+	if r.err != nil {
+		return r.err
+	}
+	var (
+		last   = r.clock.Now()
+		ticker = r.clock.NewTicker(r.interval)
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.err = ctx.Err()
+			return r.err
+		case now := <-ticker.Chan():
+			var elapsed = now.Sub(last)
+			last = now
+			if r.update(elapsed) {
+				return nil
+			}
+		}
+	}
+}
+
+// Calls Run on a new goroutine.
+func (r *Runner) Start(ctx context.Context) {
+	go r.Run(ctx)
+}
+
+// Closed once Run returns, because the animation is done or the context
+// was cancelled.
+func (r *Runner) Done() <-chan struct{} {
+	return r.done
+}
+
+// Returns why the runner stopped, which is nil if the animation finished.
+// Only valid once Done is closed.
+func (r *Runner) Err() error {
+	return r.err
+}
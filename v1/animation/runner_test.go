@@ -0,0 +1,102 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pikkpoiss/animation/v1/animation"
+	"github.com/pikkpoiss/animation/v1/animation/animationtest"
+)
+
+func TestRunner(t *testing.T) {
+	var (
+		clock  = animationtest.NewFakeClock(time.Unix(0, 0))
+		anim   = animation.NewBoundedAnimation(100 * time.Millisecond)
+		runner = animation.NewRunner(anim, 16*time.Millisecond, clock)
+	)
+	runner.Start(context.Background())
+	clock.WaitForTickers(1)
+	clock.Advance(1 * time.Second)
+	<-runner.Done()
+	if runner.Err() != nil || anim.Elapsed != 112*time.Millisecond {
+		t.Fatalf("Runner did not update until done, got %v", anim.Elapsed)
+	}
+	if clock.Tickers() != 0 {
+		t.Fatalf("Runner did not stop its ticker")
+	}
+}
+
+// Tests that elapsed time comes from the clock, whatever the tick rate.
+func TestManagerRunner(t *testing.T) {
+	var (
+		clock   = animationtest.NewFakeClock(time.Unix(0, 0))
+		manager = animation.NewAnimationManager()
+		anim    = animation.NewBoundedAnimation(1 * time.Second)
+		runner  = animation.NewManagerRunner(manager, 300*time.Millisecond, clock)
+	)
+	manager.Add(anim)
+	runner.Start(context.Background())
+	clock.WaitForTickers(1)
+	clock.Advance(1 * time.Second)
+	clock.Advance(200 * time.Millisecond)
+	<-runner.Done()
+	if runner.Err() != nil || anim.Elapsed != 1200*time.Millisecond || manager.Len() != 0 {
+		t.Fatalf("Runner did not stop once the manager was empty, got %v", anim.Elapsed)
+	}
+}
+
+func TestRunnerCancel(t *testing.T) {
+	var (
+		clock       = animationtest.NewFakeClock(time.Unix(0, 0))
+		anim        = animation.NewBoundedAnimation(1 * time.Second)
+		runner      = animation.NewRunner(anim, 16*time.Millisecond, clock)
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	runner.Start(ctx)
+	clock.WaitForTickers(1)
+	clock.Advance(100 * time.Millisecond)
+	cancel()
+	<-runner.Done()
+	if runner.Err() != context.Canceled || anim.IsDone() {
+		t.Fatalf("Runner did not stop when cancelled")
+	}
+	if clock.Tickers() != 0 {
+		t.Fatalf("Runner did not stop its ticker")
+	}
+}
+
+// Tests that mistakes are reported instead of panicking.
+func TestRunnerErrors(t *testing.T) {
+	var (
+		clock  = animationtest.NewFakeClock(time.Unix(0, 0))
+		anim   = animation.NewBoundedAnimation(100 * time.Millisecond)
+		runner = animation.NewRunner(anim, 0, clock)
+	)
+	if runner.Run(context.Background()) == nil || runner.Err() == nil {
+		t.Fatalf("Expected an error for a zero interval")
+	}
+	<-runner.Done()
+	runner = animation.NewRunner(anim, 16*time.Millisecond, clock)
+	runner.Start(context.Background())
+	clock.WaitForTickers(1)
+	clock.Advance(1 * time.Second)
+	<-runner.Done()
+	if runner.Run(context.Background()) == nil || runner.Err() != nil {
+		t.Fatalf("Expected an error for running twice")
+	}
+}
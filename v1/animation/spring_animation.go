@@ -0,0 +1,193 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"time"
+)
+
+// Granularity with which a spring checks whether it has come to rest, which
+// bounds the precision of the remainder returned by Update.
+const SpringStep = time.Millisecond
+
+// Drives a value towards a target with a damped spring.  The spring is
+// integrated exactly, so the result does not depend on how elapsed time is
+// split between calls to Update.
+type SpringAnimation struct {
+	Elapsed      time.Duration
+	Stiffness    float64
+	Damping      float64
+	Mass         float64
+	RestDelta    float64
+	RestVelocity float64
+	from         float32
+	to           float32
+	value        float64
+	velocity     float64
+	goal         float64
+	target       *float32
+	events       *Events
+	done         bool
+}
+
+func NewSpringAnimation(from, to float32, target *float32) *SpringAnimation {
+	var a = &SpringAnimation{
+		Stiffness:    170,
+		Damping:      26,
+		Mass:         1,
+		RestDelta:    0.001,
+		RestVelocity: 0.001,
+		from:         from,
+		to:           to,
+		target:       target,
+		events:       &Events{},
+	}
+	a.Reset()
+	return a
+}
+
+func (a *SpringAnimation) SetCallback(callback AnimatorCallback) {
+	a.Events().setCallback(callback)
+}
+
+func (a *SpringAnimation) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+func (a *SpringAnimation) IsDone() bool {
+	return a.done
+}
+
+func (a *SpringAnimation) Update(elapsed time.Duration) time.Duration {
+	if a.done {
+		a.events.update(true)
+		return elapsed
+	}
+	a.Elapsed += elapsed
+	for elapsed > 0 {
+		var step = SpringStep
+		if elapsed < step {
+			step = elapsed
+		}
+		a.step(step.Seconds())
+		elapsed -= step
+		if a.isSettled() {
+			a.value = a.goal
+			a.velocity = 0
+			a.done = true
+			break
+		}
+	}
+	if a.target != nil {
+		*a.target = float32(a.value)
+	}
+	a.events.update(a.done)
+	if a.done {
+		return elapsed
+	}
+	return 0
+}
+
+// Returns the spring to its starting value and original target.
+func (a *SpringAnimation) Reset() {
+	a.Elapsed = 0
+	a.value = float64(a.from)
+	a.velocity = 0
+	a.goal = float64(a.to)
+	a.done = a.isSettled()
+	a.events.reset()
+}
+
+func (a *SpringAnimation) Delete() {
+	a.events.delete()
+}
+
+// Moves the resting point of the spring.  The current value and velocity
+// are kept, so the motion stays smooth when retargeting mid-flight.
+func (a *SpringAnimation) SetTarget(to float32) {
+	a.goal = float64(to)
+	a.done = a.isSettled()
+}
+
+func (a *SpringAnimation) Target() float32 {
+	return float32(a.goal)
+}
+
+func (a *SpringAnimation) Value() float32 {
+	return float32(a.value)
+}
+
+func (a *SpringAnimation) Velocity() float32 {
+	return float32(a.velocity)
+}
+
+// Sets the current velocity, for example to hand off from a fling gesture.
+func (a *SpringAnimation) SetVelocity(velocity float32) {
+	a.velocity = float64(velocity)
+	a.done = a.isSettled()
+}
+
+func (a *SpringAnimation) isSettled() bool {
+	return math.Abs(a.value-a.goal) <= a.RestDelta && math.Abs(a.velocity) <= a.RestVelocity
+}
+
+// Advances the closed form solution of the damped harmonic oscillator.
+func (a *SpringAnimation) step(t float64) {
+	var (
+		x0    = a.value - a.goal
+		v0    = a.velocity
+		omega = math.Sqrt(a.Stiffness / a.Mass)
+		zeta  = a.Damping / (2 * math.Sqrt(a.Stiffness*a.Mass))
+		x, v  float64
+	)
+	switch {
+	case math.Abs(zeta-1) < 1e-9:
+		// Critically damped: fastest approach without overshoot.
+		var (
+			decay  = math.Exp(-omega * t)
+			factor = v0 + omega*x0
+		)
+		x = decay * (x0 + factor*t)
+		v = decay * (v0 - omega*t*factor)
+	case zeta < 1:
+		// Underdamped: oscillates around the goal.
+		var (
+			wd    = omega * math.Sqrt(1-zeta*zeta)
+			att   = zeta * omega
+			b     = (v0 + att*x0) / wd
+			cos   = math.Cos(wd * t)
+			sin   = math.Sin(wd * t)
+			decay = math.Exp(-att * t)
+		)
+		x = decay * (x0*cos + b*sin)
+		v = decay * (v0*cos - (att*b+wd*x0)*sin)
+	default:
+		// Overdamped: creeps towards the goal.
+		var (
+			root = math.Sqrt(zeta*zeta - 1)
+			r1   = -omega * (zeta - root)
+			r2   = -omega * (zeta + root)
+			c1   = (v0 - r2*x0) / (r1 - r2)
+			c2   = x0 - c1
+			e1   = math.Exp(r1 * t)
+			e2   = math.Exp(r2 * t)
+		)
+		x = c1*e1 + c2*e2
+		v = c1*r1*e1 + c2*r2*e2
+	}
+	a.value = a.goal + x
+	a.velocity = v
+}
@@ -0,0 +1,126 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Tests that a spring settles on its target and calls the callback.
+func TestSpringAnimationSettles(t *testing.T) {
+	var (
+		dest      float32 = 0
+		done              = false
+		anim              = NewSpringAnimation(0, 10, &dest)
+		remainder time.Duration
+	)
+	anim.SetCallback(func() { done = true })
+	remainder = anim.Update(100 * time.Millisecond)
+	if dest <= 0 || dest >= 10 {
+		t.Fatalf("Spring did not move towards target, got %v", dest)
+	}
+	if anim.IsDone() || done || remainder != 0 {
+		t.Fatalf("Spring marked done too early")
+	}
+	remainder = anim.Update(10 * time.Second)
+	if dest != 10 {
+		t.Fatalf("Spring did not settle on target, got %v", dest)
+	}
+	if !anim.IsDone() || !done {
+		t.Fatalf("Spring not marked done after settling")
+	}
+	if remainder <= 0 || remainder >= 10*time.Second {
+		t.Fatalf("Spring returned unexpected remainder %v", remainder)
+	}
+}
+
+// Tests that the spring does not depend on how time is split across updates.
+func TestSpringAnimationStepIndependent(t *testing.T) {
+	var (
+		coarse float32 = 0
+		fine   float32 = 0
+		a              = NewSpringAnimation(0, 10, &coarse)
+		b              = NewSpringAnimation(0, 10, &fine)
+	)
+	a.Update(250 * time.Millisecond)
+	for i := 0; i < 100; i++ {
+		b.Update(2500 * time.Microsecond)
+	}
+	if math.Abs(float64(coarse-fine)) > 1e-4 {
+		t.Fatalf("Spring results diverged: %v vs %v", coarse, fine)
+	}
+}
+
+// Tests that retargeting keeps the current value and velocity.
+func TestSpringAnimationSetTarget(t *testing.T) {
+	var (
+		dest float32 = 0
+		anim         = NewSpringAnimation(0, 10, &dest)
+	)
+	anim.Update(50 * time.Millisecond)
+	var (
+		value    = anim.Value()
+		velocity = anim.Velocity()
+	)
+	anim.SetTarget(-10)
+	if anim.Value() != value || anim.Velocity() != velocity {
+		t.Fatalf("SetTarget changed the spring state")
+	}
+	anim.Update(1 * time.Millisecond)
+	if math.Abs(float64(dest-value)) > 0.1 {
+		t.Fatalf("Spring jumped after retargeting: %v to %v", value, dest)
+	}
+	anim.Update(10 * time.Second)
+	if dest != -10 || !anim.IsDone() {
+		t.Fatalf("Spring did not settle on new target, got %v", dest)
+	}
+	anim.SetTarget(5)
+	if anim.IsDone() {
+		t.Fatalf("Spring still marked done after moving the target")
+	}
+}
+
+// Tests that critically damped and overdamped springs never overshoot.
+func TestSpringAnimationNoOvershoot(t *testing.T) {
+	for _, damping := range []float64{2 * math.Sqrt(170), 60} {
+		var (
+			dest float32 = 0
+			anim         = NewSpringAnimation(0, 10, &dest)
+		)
+		anim.Damping = damping
+		for !anim.IsDone() {
+			anim.Update(10 * time.Millisecond)
+			if dest > 10 {
+				t.Fatalf("Spring with damping %v overshot to %v", damping, dest)
+			}
+		}
+	}
+}
+
+// Tests that Reset restores the starting value and original target.
+func TestSpringAnimationReset(t *testing.T) {
+	var (
+		dest float32 = 0
+		anim         = NewSpringAnimation(0, 10, &dest)
+	)
+	anim.Update(100 * time.Millisecond)
+	anim.SetTarget(20)
+	anim.Reset()
+	if anim.Value() != 0 || anim.Velocity() != 0 || anim.Target() != 10 || anim.Elapsed != 0 {
+		t.Fatalf("Spring.Reset did not restore starting state")
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"slices"
+)
+
+// Cuts frames out of an image.  The cell at each frame's Index gives where
+// the frame is in the image.
+type SpriteSheet struct {
+	image  image.Image
+	cells  []image.Rectangle
+	images []image.Image
+}
+
+// Describes a sheet of equally sized cells, read left to right and then top
+// to bottom.  Margin is the space around the cells and Spacing the space
+// between them.  Columns and Rows default to as many as fit in the image.
+type Grid struct {
+	CellWidth  int
+	CellHeight int
+	Columns    int
+	Rows       int
+	Margin     int
+	Spacing    int
+}
+
+// Returns a sheet with cells given explicitly, for example as the Bounds of
+// an AsepriteSheet or an Atlas.
+func NewSpriteSheet(img image.Image, cells []image.Rectangle) (*SpriteSheet, error) {
+	var sheet = &SpriteSheet{image: img, cells: slices.Clone(cells)}
+	for i, cell := range cells {
+		if cell.Empty() || !cell.In(img.Bounds()) {
+			return nil, fmt.Errorf("cell %v at %v is outside the image", i, cell)
+		}
+		sheet.images = append(sheet.images, subImage(img, cell))
+	}
+	return sheet, nil
+}
+
+// Returns a sheet with cells laid out on a grid.
+func NewGridSpriteSheet(img image.Image, grid Grid) (*SpriteSheet, error) {
+	if grid.CellWidth <= 0 || grid.CellHeight <= 0 {
+		return nil, fmt.Errorf("grid cells must have a size, got %vx%v", grid.CellWidth, grid.CellHeight)
+	}
+	var (
+		bounds  = img.Bounds()
+		columns = grid.Columns
+		rows    = grid.Rows
+		cells   []image.Rectangle
+	)
+	if columns <= 0 {
+		columns = (bounds.Dx() - 2*grid.Margin + grid.Spacing) / (grid.CellWidth + grid.Spacing)
+	}
+	if rows <= 0 {
+		rows = (bounds.Dy() - 2*grid.Margin + grid.Spacing) / (grid.CellHeight + grid.Spacing)
+	}
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			var corner = bounds.Min.Add(image.Pt(
+				grid.Margin+column*(grid.CellWidth+grid.Spacing),
+				grid.Margin+row*(grid.CellHeight+grid.Spacing),
+			))
+			cells = append(cells, image.Rectangle{Min: corner, Max: corner.Add(image.Pt(grid.CellWidth, grid.CellHeight))})
+		}
+	}
+	return NewSpriteSheet(img, cells)
+}
+
+func (s *SpriteSheet) Image() image.Image {
+	return s.image
+}
+
+func (s *SpriteSheet) Len() int {
+	return len(s.cells)
+}
+
+// Returns where a frame is in the image.
+func (s *SpriteSheet) Rect(index int) (image.Rectangle, bool) {
+	if index < 0 || index >= len(s.cells) {
+		return image.Rectangle{}, false
+	}
+	return s.cells[index], true
+}
+
+// Returns a frame as an image sharing the sheet's pixels, or nil if there is
+// no such frame.
+func (s *SpriteSheet) Frame(index int) image.Image {
+	if index < 0 || index >= len(s.images) {
+		return nil
+	}
+	return s.images[index]
+}
+
+// Returns a frame animation which writes where the current frame is in the
+// sheet to rect, and the frame itself to img.  Either target may be nil.  The
+// first frame is written straight away.  Frames without a cell leave the
+// targets unchanged.
+func NewSpriteAnimation(frames []Frame, loop bool, sheet *SpriteSheet, rect *image.Rectangle, img *image.Image) *FrameAnimation {
+	var anim = NewFrameAnimation(frames, loop, nil)
+	anim.output = func(index int) {
+		var (
+			cell, ok = sheet.Rect(index)
+			frame    = sheet.Frame(index)
+		)
+		if !ok || frame == nil {
+			return
+		}
+		if rect != nil {
+			*rect = cell
+		}
+		if img != nil {
+			*img = frame
+		}
+	}
+	anim.show()
+	return anim
+}
+
+// Returns the part of an image within a rectangle.
+func subImage(img image.Image, rect image.Rectangle) image.Image {
+	type subImager interface {
+		SubImage(r image.Rectangle) image.Image
+	}
+	if s, ok := img.(subImager); ok {
+		return s.SubImage(rect)
+	}
+	var copied = image.NewRGBA(rect)
+	draw.Draw(copied, rect, img, rect.Min, draw.Src)
+	return copied
+}
@@ -0,0 +1,92 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestGridSpriteSheet(t *testing.T) {
+	var (
+		img   = image.NewRGBA(image.Rect(0, 0, 36, 25))
+		sheet *SpriteSheet
+		err   error
+	)
+	if sheet, err = NewGridSpriteSheet(img, Grid{CellWidth: 10, CellHeight: 10, Margin: 2, Spacing: 1}); err != nil {
+		t.Fatalf("Could not slice sheet: %v", err)
+	}
+	if sheet.Len() != 6 {
+		t.Fatalf("Expected 3 columns and 2 rows, got %v cells", sheet.Len())
+	}
+	if rect, _ := sheet.Rect(4); rect != image.Rect(13, 13, 23, 23) {
+		t.Fatalf("Unexpected cell %v", rect)
+	}
+	if _, ok := sheet.Rect(6); ok || sheet.Frame(-1) != nil {
+		t.Fatalf("Expected no frame outside the sheet")
+	}
+	if sheet, _ = NewGridSpriteSheet(img, Grid{CellWidth: 10, CellHeight: 10, Columns: 2, Rows: 1}); sheet.Len() != 2 {
+		t.Fatalf("Expected the given columns and rows, got %v cells", sheet.Len())
+	}
+	if _, err = NewGridSpriteSheet(img, Grid{CellWidth: 10, CellHeight: 10, Columns: 4}); err == nil {
+		t.Fatalf("Expected an error for cells outside the image")
+	}
+	if _, err = NewGridSpriteSheet(img, Grid{CellWidth: 10}); err == nil {
+		t.Fatalf("Expected an error for empty cells")
+	}
+}
+
+func TestSpriteSheetFrame(t *testing.T) {
+	var (
+		img      = image.NewRGBA(image.Rect(0, 0, 8, 4))
+		sheet, _ = NewSpriteSheet(img, []image.Rectangle{image.Rect(0, 0, 4, 4), image.Rect(4, 0, 8, 4)})
+	)
+	img.SetRGBA(5, 1, color.RGBA{255, 0, 0, 255})
+	var frame = sheet.Frame(1)
+	if frame.Bounds() != image.Rect(4, 0, 8, 4) || frame.At(5, 1) != (color.RGBA{255, 0, 0, 255}) {
+		t.Fatalf("Frame should share the sheet's pixels")
+	}
+}
+
+func TestSpriteAnimation(t *testing.T) {
+	var (
+		img      = image.NewRGBA(image.Rect(0, 0, 16, 8))
+		sheet, _ = NewGridSpriteSheet(img, Grid{CellWidth: 8, CellHeight: 8})
+		rect     image.Rectangle
+		frame    image.Image
+		anim     = NewSpriteAnimation([]Frame{MsFrame(100, 1), MsFrame(100, 0), MsFrame(100, 7)}, false, sheet, &rect, &frame)
+		seen     image.Rectangle
+	)
+	anim.SetCallback(func() {
+		seen = rect
+	})
+	if rect != image.Rect(8, 0, 16, 8) || frame == nil {
+		t.Fatalf("Expected the first frame before updating, got %v", rect)
+	}
+	anim.Update(50 * time.Millisecond)
+	if rect != image.Rect(8, 0, 16, 8) || frame.Bounds() != rect {
+		t.Fatalf("Expected the second cell, got %v", rect)
+	}
+	anim.Seek(150*time.Millisecond, SeekSilent)
+	if rect != image.Rect(0, 0, 8, 8) || frame.Bounds() != rect {
+		t.Fatalf("Expected the first cell, got %v", rect)
+	}
+	anim.Update(200 * time.Millisecond)
+	if rect != image.Rect(0, 0, 8, 8) || seen != rect {
+		t.Fatalf("Frames without a cell should leave the targets alone, got %v", rect)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"slices"
+	"time"
+)
+
+// What happens when an animation starts on a target which is already being
+// animated.
+type Overwrite int
+
+const (
+	// Cancels the animations already running on the target.
+	OverwriteKill Overwrite = iota
+	// Fades from the running animations to the new one over the blend
+	// duration, then cancels the old ones.
+	OverwriteBlend
+	// Starts the new animation once those already on the target finish.  It
+	// is built when it starts, so it sees the value they left behind.
+	OverwriteQueue
+	// Drops the new animation, leaving the running ones alone.
+	OverwriteIgnore
+)
+
+// Builds an animator which writes to the given target.
+type TargetBuilder func(target *float32) Animator
+
+// Tracks which animations write to which targets, so that starting a new
+// animation on a target that is already animated behaves predictably.
+// Animations are run by an AnimationManager.
+type TargetRegistry struct {
+	BlendDuration time.Duration
+	manager       *AnimationManager
+	owners        map[*float32][]*targetAnimator
+}
+
+func NewTargetRegistry(manager *AnimationManager) *TargetRegistry {
+	return &TargetRegistry{
+		BlendDuration: 250 * time.Millisecond,
+		manager:       manager,
+		owners:        map[*float32][]*targetAnimator{},
+	}
+}
+
+// Starts an animation on a target.  The returned handle refers to the
+// animation in the manager, and is zero if the animation was ignored.
+func (r *TargetRegistry) Animate(target *float32, mode Overwrite, build TargetBuilder, tags ...string) Handle {
+	var (
+		owners = r.owners[target]
+		busy   = len(owners) > 0
+		entry  = &targetAnimator{registry: r, target: target}
+	)
+	switch {
+	case !busy:
+		entry.animator = build(target)
+	case mode == OverwriteIgnore:
+		return 0
+	case mode == OverwriteKill:
+		r.Kill(target)
+		entry.animator = build(target)
+	case mode == OverwriteQueue:
+		entry.waiting = true
+		entry.build = build
+	case mode == OverwriteBlend:
+		entry.shadowed = true
+		entry.blending = true
+		entry.shadow = *target
+		entry.outgoing = *target
+		entry.animator = build(&entry.shadow)
+	}
+	r.owners[target] = append(r.owners[target], entry)
+	entry.handle = r.manager.Add(entry, tags...)
+	return entry.handle
+}
+
+// Reports whether any animation is running or queued on a target.
+func (r *TargetRegistry) IsAnimating(target *float32) bool {
+	return len(r.owners[target]) > 0
+}
+
+// Cancels every animation on a target, returning how many there were.
+func (r *TargetRegistry) Kill(target *float32) int {
+	var owners = slices.Clone(r.owners[target])
+	for _, entry := range owners {
+		r.manager.Cancel(entry.handle)
+	}
+	return len(owners)
+}
+
+// Forgets an animation once it has been deleted, starting the next queued
+// animation if nothing else is running on the target.
+func (r *TargetRegistry) release(entry *targetAnimator) {
+	var owners = slices.DeleteFunc(r.owners[entry.target], func(other *targetAnimator) bool {
+		return other == entry
+	})
+	if len(owners) == 0 {
+		delete(r.owners, entry.target)
+		return
+	}
+	r.owners[entry.target] = owners
+	r.advance(entry.target, 0, false)
+}
+
+// Starts the first queued animation on a target once every animation before
+// it has finished.  Time left over from the finished animation is passed on
+// when it finished during the current update.
+func (r *TargetRegistry) advance(target *float32, remainder time.Duration, carry bool) {
+	for _, entry := range r.owners[target] {
+		if entry.waiting {
+			entry.start()
+			entry.carry = max(remainder, 0)
+			entry.carried = carry
+			return
+		}
+		if !entry.IsDone() {
+			return
+		}
+	}
+}
+
+// Wraps an animator started through a TargetRegistry.
+type targetAnimator struct {
+	registry *TargetRegistry
+	animator Animator
+	build    TargetBuilder
+	callback AnimatorCallback
+	target   *float32
+	handle   Handle
+	waiting  bool
+	carry    time.Duration
+	carried  bool
+	shadowed bool
+	blending bool
+	shadow   float32
+	outgoing float32
+	value    float32
+	weight   time.Duration
+}
+
+// Builds a queued animator now that it is its turn.
+func (a *targetAnimator) start() {
+	a.waiting = false
+	a.animator = a.build(a.target)
+	if a.callback != nil {
+		a.animator.SetCallback(a.callback)
+	}
+}
+
+func (a *targetAnimator) SetCallback(callback AnimatorCallback) {
+	if a.animator == nil {
+		a.callback = callback
+		return
+	}
+	a.animator.SetCallback(callback)
+}
+
+func (a *targetAnimator) IsDone() bool {
+	return !a.waiting && a.animator.IsDone()
+}
+
+func (a *targetAnimator) Update(elapsed time.Duration) time.Duration {
+	if a.waiting {
+		return 0
+	}
+	if a.carried {
+		elapsed = a.carry
+		a.carried = false
+	}
+	var remainder = a.animator.Update(elapsed)
+	if a.blending {
+		a.blend(elapsed)
+	} else if a.shadowed {
+		*a.target = a.shadow
+	}
+	a.value = *a.target
+	if a.animator.IsDone() {
+		a.registry.advance(a.target, remainder, true)
+	}
+	return remainder
+}
+
+// Mixes the value written by this animator over the value left by the
+// animator started before it.  Once that one has gone, the last value it
+// left is used.  The blend ends early if this animator finishes first.
+func (a *targetAnimator) blend(elapsed time.Duration) {
+	var duration = a.registry.BlendDuration
+	a.weight += elapsed
+	if a.weight >= duration || a.animator.IsDone() {
+		a.blending = false
+		*a.target = a.shadow
+		a.takeOver()
+		return
+	}
+	if previous := a.previous(); previous != nil {
+		a.outgoing = previous.value
+	}
+	var t = float64(a.weight) / float64(duration)
+	*a.target = LerpFloat(a.outgoing, a.shadow, t)
+}
+
+// Returns the running animator started on the target just before this one.
+func (a *targetAnimator) previous() *targetAnimator {
+	var previous *targetAnimator
+	for _, entry := range a.registry.owners[a.target] {
+		if entry == a {
+			break
+		}
+		if !entry.waiting {
+			previous = entry
+		}
+	}
+	return previous
+}
+
+// Cancels the animators this one was blending from.
+func (a *targetAnimator) takeOver() {
+	for _, entry := range slices.Clone(a.registry.owners[a.target]) {
+		if entry == a {
+			return
+		}
+		a.registry.manager.Cancel(entry.handle)
+	}
+}
+
+func (a *targetAnimator) Reset() {
+	if a.animator != nil {
+		a.animator.Reset()
+	}
+}
+
+func (a *targetAnimator) Delete() {
+	if a.animator != nil {
+		a.animator.Delete()
+	}
+	a.registry.release(a)
+}
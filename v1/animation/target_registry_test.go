@@ -0,0 +1,166 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+// Returns a builder for a linear animation from the current value.
+func linearTo(to float32, duration time.Duration) TargetBuilder {
+	return func(target *float32) Animator {
+		return NewContinuousAnimation(LinearFunc(duration, *target, to), target)
+	}
+}
+
+func TestTargetRegistryKill(t *testing.T) {
+	var (
+		manager  = NewAnimationManager()
+		registry = NewTargetRegistry(manager)
+		x        float32
+		first    = registry.Animate(&x, OverwriteKill, linearTo(10, 1*time.Second))
+	)
+	manager.Update(500 * time.Millisecond)
+	registry.Animate(&x, OverwriteKill, linearTo(0, 1*time.Second))
+	if manager.Contains(first) || manager.Len() != 1 {
+		t.Fatalf("OverwriteKill did not cancel the running animation")
+	}
+	manager.Update(500 * time.Millisecond)
+	if x != 2.5 {
+		t.Fatalf("Target value does not match expected, got %v", x)
+	}
+}
+
+func TestTargetRegistryIgnore(t *testing.T) {
+	var (
+		manager  = NewAnimationManager()
+		registry = NewTargetRegistry(manager)
+		x        float32
+	)
+	registry.Animate(&x, OverwriteIgnore, linearTo(10, 1*time.Second))
+	if registry.Animate(&x, OverwriteIgnore, linearTo(0, 1*time.Second)) != 0 {
+		t.Fatalf("OverwriteIgnore started an animation on a busy target")
+	}
+	manager.Update(2 * time.Second)
+	if x != 10 || registry.IsAnimating(&x) {
+		t.Fatalf("Target value does not match expected, got %v", x)
+	}
+	if registry.Animate(&x, OverwriteIgnore, linearTo(0, 1*time.Second)) == 0 {
+		t.Fatalf("OverwriteIgnore did not start an animation on an idle target")
+	}
+}
+
+func TestTargetRegistryQueue(t *testing.T) {
+	var (
+		manager  = NewAnimationManager()
+		registry = NewTargetRegistry(manager)
+		x        float32
+	)
+	registry.Animate(&x, OverwriteQueue, linearTo(10, 1*time.Second))
+	registry.Animate(&x, OverwriteQueue, linearTo(20, 1*time.Second))
+	manager.Update(500 * time.Millisecond)
+	if x != 5 {
+		t.Fatalf("Queued animation ran too early, got %v", x)
+	}
+	manager.Update(750 * time.Millisecond)
+	if x != 12.5 {
+		t.Fatalf("Queued animation did not take leftover time, got %v", x)
+	}
+	manager.Update(1 * time.Second)
+	if x != 20 || registry.IsAnimating(&x) {
+		t.Fatalf("Target value does not match expected, got %v", x)
+	}
+}
+
+func TestTargetRegistryBlend(t *testing.T) {
+	var (
+		manager  = NewAnimationManager()
+		registry = NewTargetRegistry(manager)
+		x        float32
+		first    = registry.Animate(&x, OverwriteBlend, func(target *float32) Animator {
+			return NewContinuousAnimation(func(time.Duration) (float32, bool, time.Duration) {
+				return 10, false, 0
+			}, target)
+		})
+	)
+	registry.BlendDuration = 1 * time.Second
+	manager.Update(100 * time.Millisecond)
+	registry.Animate(&x, OverwriteBlend, func(target *float32) Animator {
+		return NewContinuousAnimation(func(time.Duration) (float32, bool, time.Duration) {
+			return 20, false, 0
+		}, target)
+	})
+	manager.Update(500 * time.Millisecond)
+	if x != 15 {
+		t.Fatalf("Blended value does not match expected, got %v", x)
+	}
+	manager.Update(500 * time.Millisecond)
+	if x != 20 || manager.Contains(first) {
+		t.Fatalf("Blend did not take over the target, got %v", x)
+	}
+	manager.Update(500 * time.Millisecond)
+	if x != 20 {
+		t.Fatalf("Target value does not match expected, got %v", x)
+	}
+}
+
+// Tests that a blend keeps fading from the last value of an animation which
+// finishes part way through.
+func TestTargetRegistryBlendFinished(t *testing.T) {
+	var (
+		manager  = NewAnimationManager()
+		registry = NewTargetRegistry(manager)
+		x        float32
+		first    = registry.Animate(&x, OverwriteBlend, linearTo(10, 200*time.Millisecond))
+	)
+	registry.BlendDuration = 1 * time.Second
+	manager.Update(100 * time.Millisecond)
+	registry.Animate(&x, OverwriteBlend, func(target *float32) Animator {
+		return NewContinuousAnimation(func(time.Duration) (float32, bool, time.Duration) {
+			return 20, false, 0
+		}, target)
+	})
+	manager.Update(100 * time.Millisecond)
+	if x != 11 || manager.Contains(first) {
+		t.Fatalf("Blended value does not match expected, got %v", x)
+	}
+	manager.Update(400 * time.Millisecond)
+	if x != 15 {
+		t.Fatalf("Blend should fade from the finished value, got %v", x)
+	}
+	manager.Update(250 * time.Millisecond)
+	if x != 17.5 {
+		t.Fatalf("Blended value does not match expected, got %v", x)
+	}
+}
+
+func TestTargetRegistryBlendShorter(t *testing.T) {
+	var (
+		manager  = NewAnimationManager()
+		registry = NewTargetRegistry(manager)
+		x        float32
+		first    = registry.Animate(&x, OverwriteBlend, linearTo(100, 10*time.Second))
+	)
+	registry.Animate(&x, OverwriteBlend, linearTo(40, 100*time.Millisecond))
+	manager.Update(100 * time.Millisecond)
+	if x != 40 || manager.Contains(first) {
+		t.Fatalf("A finished blend should take over the target, got %v", x)
+	}
+	manager.Update(1 * time.Second)
+	if x != 40 {
+		t.Fatalf("Cancelled animation kept writing the target, got %v", x)
+	}
+}
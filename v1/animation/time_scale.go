@@ -0,0 +1,206 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"time"
+)
+
+// Implemented by animators which run their children faster or slower than
+// real time.  Scales multiply, so a child at 0.5 inside a group at 0.5 runs
+// at a quarter speed.  A scale of 0 freezes the children for Update, but
+// they can still be sought and measured at the last scale which was not 0.
+type TimeScalable interface {
+	SetTimeScale(scale float64)
+	TimeScale() float64
+}
+
+// Converts time from a parent into the scaled time of a child.
+func scaleDuration(elapsed time.Duration, scale float64) time.Duration {
+	return clampDuration(float64(elapsed) * scale)
+}
+
+// Converts time from a scaled child back into the time of its parent.
+func unscaleDuration(elapsed time.Duration, scale float64) time.Duration {
+	if scale == 0 {
+		return 0
+	}
+	return clampDuration(float64(elapsed) / scale)
+}
+
+func clampDuration(d float64) time.Duration {
+	switch {
+	case d >= math.MaxInt64:
+		return math.MaxInt64
+	case d <= math.MinInt64:
+		return math.MinInt64
+	}
+	return time.Duration(math.Round(d))
+}
+
+// Negative scales are treated as 0.
+func validScale(scale float64) float64 {
+	return math.Max(scale, 0)
+}
+
+// A time scale along with the last scale which was not 0.  Updates use the
+// scale, so that 0 freezes, as does pausing.  Positions and lengths use the
+// last scale which was not 0, so that a frozen animation can still be sought
+// and measured.
+type timeScale struct {
+	scale  float64
+	last   float64
+	paused bool
+}
+
+func newTimeScale(scale float64) timeScale {
+	var s = timeScale{1, 1, false}
+	s.set(scale)
+	return s
+}
+
+func (s *timeScale) set(scale float64) {
+	s.scale = validScale(scale)
+	if s.scale > 0 {
+		s.last = s.scale
+	}
+}
+
+func (s timeScale) frozen() bool {
+	return s.scale == 0 || s.paused
+}
+
+// Converts time from a parent into the scaled time of a child.
+func (s timeScale) toChild(elapsed time.Duration) time.Duration {
+	return scaleDuration(elapsed, s.last)
+}
+
+// Converts time from a scaled child back into the time of its parent.
+func (s timeScale) toParent(elapsed time.Duration) time.Duration {
+	return unscaleDuration(elapsed, s.last)
+}
+
+// Runs an animator at a multiple of the elapsed time, for slow motion and
+// hit-stop.  Remainders are converted back into unscaled time.
+type TimeScaled struct {
+	animator Animator
+	scale    timeScale
+	events   *Events
+}
+
+func NewTimeScaled(animator Animator, scale float64) *TimeScaled {
+	return &TimeScaled{animator, newTimeScale(scale), &Events{}}
+}
+
+func (a *TimeScaled) SetTimeScale(scale float64) {
+	a.scale.set(scale)
+}
+
+func (a *TimeScaled) TimeScale() float64 {
+	return a.scale.scale
+}
+
+func (a *TimeScaled) Pause() {
+	a.scale.paused = true
+}
+
+// Continues from where the animator was paused, at the same scale.  Time
+// which passed while paused is not made up.
+func (a *TimeScaled) Resume() {
+	a.scale.paused = false
+}
+
+func (a *TimeScaled) IsPaused() bool {
+	return a.scale.paused
+}
+
+// Returns the wrapped animator.
+func (a *TimeScaled) Animator() Animator {
+	return a.animator
+}
+
+func (a *TimeScaled) SetCallback(callback AnimatorCallback) {
+	a.Events().setCallback(callback)
+}
+
+func (a *TimeScaled) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+func (a *TimeScaled) IsDone() bool {
+	return a.animator.IsDone()
+}
+
+// While frozen, all of the elapsed time is used up unless the wrapped
+// animator has already finished.
+func (a *TimeScaled) Update(elapsed time.Duration) time.Duration {
+	var remainder = a.advance(elapsed)
+	a.events.update(a.IsDone())
+	return remainder
+}
+
+func (a *TimeScaled) advance(elapsed time.Duration) time.Duration {
+	if a.scale.frozen() {
+		if a.animator.IsDone() {
+			return elapsed
+		}
+		return 0
+	}
+	return a.scale.toParent(a.animator.Update(a.scale.toChild(elapsed)))
+}
+
+func (a *TimeScaled) canRewind() bool {
+	return canRewind(a.animator)
+}
+
+// Resetting does not resume.
+func (a *TimeScaled) Reset() {
+	a.animator.Reset()
+	a.events.reset()
+}
+
+func (a *TimeScaled) Delete() {
+	a.events.delete()
+	a.animator.Delete()
+}
+
+// Positions are in unscaled time.  A frozen animator can still be moved.
+func (a *TimeScaled) Seek(position time.Duration, mode SeekMode) time.Duration {
+	var remainder = SeekAnimator(a.animator, a.scale.toChild(position), mode)
+	a.events.seek(a.IsDone(), mode)
+	return a.scale.toParent(remainder)
+}
+
+func (a *TimeScaled) Position() time.Duration {
+	return a.scale.toParent(PositionOf(a.animator))
+}
+
+// Returns the unscaled length, measured at the last scale which was not 0
+// while frozen.
+func (a *TimeScaled) Length() time.Duration {
+	return a.scale.toParent(LengthOf(a.animator))
+}
+
+func (a *TimeScaled) SetDirection(direction Direction) {
+	SetAnimatorDirection(a.animator, direction)
+}
+
+func (a *TimeScaled) Direction() Direction {
+	if d, ok := a.animator.(Directional); ok {
+		return d.Direction()
+	}
+	return Forward
+}
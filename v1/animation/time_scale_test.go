@@ -0,0 +1,126 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeScaled(t *testing.T) {
+	var (
+		done  = false
+		child = NewBoundedAnimation(1 * time.Second)
+		anim  = NewTimeScaled(child, 0.5)
+		resp  time.Duration
+	)
+	anim.SetCallback(func() { done = true })
+	resp = anim.Update(1 * time.Second)
+	if child.Elapsed != 500*time.Millisecond || resp != 0 || done {
+		t.Fatalf("TimeScaled did not slow down its child, got %v", child.Elapsed)
+	}
+	if anim.Position() != 1*time.Second || anim.Length() != 2*time.Second {
+		t.Fatalf("TimeScaled did not report unscaled time")
+	}
+	resp = anim.Update(1500 * time.Millisecond)
+	if resp != 500*time.Millisecond || !done {
+		t.Fatalf("TimeScaled remainder was not unscaled, got %v", resp)
+	}
+}
+
+// Tests that a frozen animation uses up all of the elapsed time.
+func TestTimeScaledFrozen(t *testing.T) {
+	var (
+		child = NewBoundedAnimation(1 * time.Second)
+		anim  = NewTimeScaled(child, 0)
+	)
+	if anim.Update(5*time.Second) != 0 || child.Elapsed != 0 {
+		t.Fatalf("Frozen TimeScaled advanced its child")
+	}
+	anim.SetTimeScale(2)
+	if anim.Update(600*time.Millisecond) != 100*time.Millisecond || !anim.IsDone() {
+		t.Fatalf("TimeScaled did not speed up its child, got %v", child.Elapsed)
+	}
+	anim.SetTimeScale(0)
+	if anim.Update(5*time.Second) != 5*time.Second {
+		t.Fatalf("Frozen TimeScaled held on to time after finishing")
+	}
+}
+
+// Tests that scales multiply through nested groups.
+// Tests that a frozen animation can still be sought and measured.
+func TestTimeScaledFrozenSeek(t *testing.T) {
+	var (
+		child   = NewBoundedAnimation(1 * time.Second)
+		anim    = NewTimeScaled(child, 0)
+		chained = NewChainedAnimation([]Animator{anim, NewBoundedAnimation(1 * time.Second)}, false)
+	)
+	anim.Seek(500*time.Millisecond, SeekSilent)
+	if child.Elapsed != 500*time.Millisecond || anim.Length() != 1*time.Second {
+		t.Fatalf("Frozen TimeScaled could not be sought, got %v", child.Elapsed)
+	}
+	anim.SetTimeScale(2)
+	anim.SetTimeScale(0)
+	anim.Seek(250*time.Millisecond, SeekSilent)
+	if child.Elapsed != 500*time.Millisecond || anim.Position() != 250*time.Millisecond {
+		t.Fatalf("Frozen TimeScaled should seek at its last scale, got %v", child.Elapsed)
+	}
+	if anim.Length() != 500*time.Millisecond || chained.Length() != 1500*time.Millisecond {
+		t.Fatalf("Frozen TimeScaled should be measured at its last scale, got %v", anim.Length())
+	}
+	chained.SetTimeScale(0)
+	if chained.Seek(1*time.Second, SeekSilent) != 0 || !anim.IsDone() {
+		t.Fatalf("Frozen ChainedAnimation could not be sought, got %v", chained.Position())
+	}
+	if chained.Position() != 1*time.Second || chained.Length() != 1500*time.Millisecond {
+		t.Fatalf("Frozen ChainedAnimation should report unscaled time, got %v", chained.Position())
+	}
+}
+
+func TestTimeScaleHierarchy(t *testing.T) {
+	var (
+		child = NewBoundedAnimation(1 * time.Second)
+		inner = NewTimeScaled(child, 0.5)
+		group = NewGroupedAnimation([]Animator{inner})
+		chain = NewChainedAnimation([]Animator{group}, false)
+		resp  time.Duration
+	)
+	group.SetTimeScale(0.5)
+	chain.SetTimeScale(2)
+	chain.Update(1 * time.Second)
+	if child.Elapsed != 500*time.Millisecond {
+		t.Fatalf("Time scales did not multiply, got %v", child.Elapsed)
+	}
+	if chain.Length() != 2*time.Second || chain.Position() != 1*time.Second {
+		t.Fatalf("Nested lengths were not unscaled, got %v", chain.Length())
+	}
+	chain.SetTimeScale(0.25)
+	resp = chain.Update(20 * time.Second)
+	if resp != 12*time.Second || !child.IsDone() {
+		t.Fatalf("Nested remainder was not unscaled, got %v", resp)
+	}
+}
+
+func TestScaleDuration(t *testing.T) {
+	if scaleDuration(3, 1.0/3) != 1 || unscaleDuration(1, 1.0/3) != 3 {
+		t.Fatalf("Fractional scales did not round to the nearest nanosecond")
+	}
+	if scaleDuration(continuousHorizon, 4) != time.Duration(1<<63-1) {
+		t.Fatalf("Scaled durations did not clamp")
+	}
+	if NewTimeScaled(nil, -1).TimeScale() != 0 {
+		t.Fatalf("Negative scale was not treated as frozen")
+	}
+}
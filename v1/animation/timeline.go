@@ -0,0 +1,382 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type timelineChild struct {
+	animator Animator
+	start    time.Duration
+	length   time.Duration
+	finish   time.Duration
+	done     bool
+}
+
+// Runs children at fixed positions in time.  Positions are given as strings:
+//
+//	""           at the end of the timeline
+//	"1.5s"       at an absolute time
+//	"+=200ms"    relative to the end of the timeline ("-=200ms" to overlap)
+//	"<", "<1s"   relative to the start of the previously added child
+//	">", ">-1s"  relative to the end of the previously added child
+//	"impact"     at a label ("impact+=1s" and "impact-=1s" to offset)
+//
+// Children which are not Measurable count as zero length when placing later
+// children, but still run until they are done.
+type Timeline struct {
+	Elapsed   time.Duration
+	children  []*timelineChild
+	labels    map[string]time.Duration
+	events    *Events
+	done      bool
+	direction Direction
+	returning bool
+	legStart  time.Duration
+}
+
+func NewTimeline() *Timeline {
+	return &Timeline{labels: map[string]time.Duration{}, events: &Events{}}
+}
+
+// Inserts a child at a position.
+func (a *Timeline) Add(animator Animator, position string) error {
+	return a.AddSpan(animator, LengthOf(animator), position)
+}
+
+// Inserts a child at a position, giving its length explicitly.  Use this for
+// children which are not Measurable.
+func (a *Timeline) AddSpan(animator Animator, length time.Duration, position string) error {
+	var (
+		start time.Duration
+		err   error
+	)
+	if start, err = a.resolve(position); err != nil {
+		return err
+	}
+	a.children = append(a.children, &timelineChild{
+		animator: animator,
+		start:    start,
+		length:   length,
+	})
+	a.done = false
+	return nil
+}
+
+// Names a position so later children can be placed relative to it.
+func (a *Timeline) AddLabel(name string, position string) error {
+	if name == "" || strings.ContainsAny(name, "<>+-=") {
+		return fmt.Errorf("invalid label name %q", name)
+	}
+	var (
+		at  time.Duration
+		err error
+	)
+	if at, err = a.resolve(position); err != nil {
+		return err
+	}
+	a.labels[name] = at
+	return nil
+}
+
+func (a *Timeline) Label(name string) (time.Duration, bool) {
+	var at, ok = a.labels[name]
+	return at, ok
+}
+
+// Returns how long the timeline plays for in its current direction.
+func (a *Timeline) Length() time.Duration {
+	return passLength(a.legLength(), a.direction)
+}
+
+// Returns when the last child is scheduled to end.
+func (a *Timeline) legLength() time.Duration {
+	var length time.Duration
+	for _, child := range a.children {
+		if end := child.start + child.length; end > length {
+			length = end
+		}
+	}
+	return length
+}
+
+func (a *Timeline) resolve(position string) (at time.Duration, err error) {
+	var (
+		end      = a.legLength()
+		base     time.Duration
+		offset   string
+		previous *timelineChild
+	)
+	if count := len(a.children); count > 0 {
+		previous = a.children[count-1]
+	}
+	switch {
+	case position == "":
+		return end, nil
+	case strings.HasPrefix(position, "+=") || strings.HasPrefix(position, "-="):
+		base, offset = end, position
+	case strings.HasPrefix(position, "<") || strings.HasPrefix(position, ">"):
+		if previous != nil {
+			base = previous.start
+			if position[0] == '>' {
+				base += previous.length
+			}
+		}
+		offset = position[1:]
+	default:
+		var index = strings.IndexAny(position, "+-")
+		if index < 0 {
+			index = len(position)
+		}
+		var label, ok = a.labels[position[:index]]
+		if !ok {
+			// Not a label, so it has to be an absolute time.
+			if at, err = time.ParseDuration(position); err != nil {
+				return 0, fmt.Errorf("unknown timeline position %q", position)
+			}
+			if at < 0 {
+				return 0, fmt.Errorf("timeline position %q is before the start", position)
+			}
+			return at, nil
+		}
+		base, offset = label, position[index:]
+	}
+	var delta time.Duration
+	if delta, err = parseOffset(offset); err != nil {
+		return 0, fmt.Errorf("invalid offset in timeline position %q", position)
+	}
+	if at = base + delta; at < 0 {
+		return 0, fmt.Errorf("timeline position %q is before the start", position)
+	}
+	return at, nil
+}
+
+// Parses offsets of the form "", "200ms", "-1s", "+=1s" or "-=1s".
+func parseOffset(offset string) (time.Duration, error) {
+	if offset == "" {
+		return 0, nil
+	}
+	if strings.HasPrefix(offset, "+=") || strings.HasPrefix(offset, "-=") {
+		offset = offset[:1] + offset[2:]
+	}
+	return time.ParseDuration(offset)
+}
+
+func (a *Timeline) SetCallback(callback AnimatorCallback) {
+	a.Events().setCallback(callback)
+}
+
+func (a *Timeline) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+func (a *Timeline) IsDone() bool {
+	if a.direction == PingPong && !a.returning {
+		return false
+	}
+	return a.legDone()
+}
+
+// Reports whether the current leg has finished.  A PingPong timeline has an
+// outward and a returning leg; other timelines have only one.
+func (a *Timeline) legDone() bool {
+	if a.Elapsed < a.legStart+a.legLength() {
+		return false
+	}
+	for _, child := range a.children {
+		if !child.done {
+			return false
+		}
+	}
+	return true
+}
+
+// Negative elapsed time moves the timeline back towards its start.  It is
+// ignored if any child is not a Measurable Seeker.
+func (a *Timeline) Update(elapsed time.Duration) time.Duration {
+	var remainder = a.play(elapsed)
+	a.events.update(a.done)
+	return remainder
+}
+
+func (a *Timeline) play(elapsed time.Duration) time.Duration {
+	if elapsed < 0 {
+		if a.canRewind() {
+			a.Seek(max(a.Elapsed+elapsed, 0), SeekSilent)
+		}
+		return 0
+	}
+	if a.done {
+		return elapsed
+	}
+	var previous = a.Elapsed
+	a.Elapsed += elapsed
+	a.advance(previous)
+	if a.direction == PingPong && !a.returning && a.legDone() {
+		var turn = a.finish()
+		a.turn(true, turn)
+		a.restart()
+		a.advance(turn)
+	}
+	if !a.IsDone() {
+		return 0
+	}
+	a.done = true
+	return a.Elapsed - a.finish()
+}
+
+func (a *Timeline) canRewind() bool {
+	for _, child := range a.children {
+		if !canRewind(child.animator) {
+			return false
+		}
+	}
+	return true
+}
+
+// Runs the children of the current leg from previous up to Elapsed.
+func (a *Timeline) advance(previous time.Duration) {
+	for _, child := range a.children {
+		var start = a.legStart + a.childStart(child)
+		if child.done || a.Elapsed <= start {
+			continue
+		}
+		var (
+			from      = max(previous, start)
+			remainder = child.animator.Update(a.Elapsed - from)
+		)
+		if child.animator.IsDone() {
+			child.done = true
+			child.finish = a.Elapsed - max(remainder, 0)
+		}
+	}
+}
+
+// Returns when the current leg finished, which is when its last child did.
+func (a *Timeline) finish() time.Duration {
+	var finish = a.legStart + a.legLength()
+	for _, child := range a.children {
+		if child.finish > finish {
+			finish = child.finish
+		}
+	}
+	return finish
+}
+
+// Returns when a child starts within a leg.  Playing backwards mirrors the
+// timeline, so each child ends where it would otherwise have started.
+func (a *Timeline) childStart(child *timelineChild) time.Duration {
+	if a.backwards() {
+		return a.legLength() - child.start - child.length
+	}
+	return child.start
+}
+
+func (a *Timeline) backwards() bool {
+	return a.direction == Reverse || a.returning
+}
+
+// Points Directional children the way the current leg plays.
+func (a *Timeline) orient() {
+	var direction = Forward
+	if a.backwards() {
+		direction = Reverse
+	}
+	for _, child := range a.children {
+		SetAnimatorDirection(child.animator, direction)
+	}
+}
+
+// Starts a leg at the given time.  The children are pointed the way the leg
+// plays but not moved.
+func (a *Timeline) turn(returning bool, at time.Duration) {
+	a.returning = returning
+	a.legStart = at
+	a.orient()
+	for _, child := range a.children {
+		child.done = false
+		child.finish = 0
+	}
+}
+
+// Resets the children to play the current leg.
+func (a *Timeline) restart() {
+	for _, child := range a.children {
+		child.animator.Reset()
+	}
+}
+
+// Plays the timeline mirrored when reversed.  The direction is also applied
+// to Directional children, so that each of them plays backwards too.
+func (a *Timeline) SetDirection(direction Direction) {
+	a.direction = direction
+	a.returning = false
+	a.legStart = 0
+	a.orient()
+}
+
+func (a *Timeline) Direction() Direction {
+	return a.direction
+}
+
+func (a *Timeline) Seek(position time.Duration, mode SeekMode) time.Duration {
+	var leg = a.legLength()
+	a.Elapsed = max(position, 0)
+	a.done = false
+	if a.direction == PingPong && leg > 0 && a.Elapsed >= leg {
+		a.turn(true, leg)
+	} else {
+		a.turn(false, 0)
+	}
+	for _, child := range a.children {
+		var (
+			local     = a.Elapsed - a.legStart - a.childStart(child)
+			remainder = SeekAnimator(child.animator, max(local, 0), mode)
+		)
+		child.done = local > 0 && child.animator.IsDone()
+		if child.done {
+			child.finish = a.Elapsed - max(remainder, 0)
+		}
+	}
+	a.done = a.IsDone()
+	a.events.seek(a.done, mode)
+	if !a.done {
+		return 0
+	}
+	return a.Elapsed - a.finish()
+}
+
+func (a *Timeline) Position() time.Duration {
+	return a.Elapsed
+}
+
+func (a *Timeline) Reset() {
+	a.Elapsed = 0
+	a.done = false
+	a.turn(false, 0)
+	a.restart()
+	a.events.reset()
+}
+
+func (a *Timeline) Delete() {
+	a.events.delete()
+	for _, child := range a.children {
+		child.animator.Delete()
+	}
+	a.children = []*timelineChild{}
+}
@@ -0,0 +1,239 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests that positions resolve against the end, previous child and labels.
+func TestTimelinePositions(t *testing.T) {
+	var (
+		anim     = NewTimeline()
+		child1   = NewBoundedAnimation(1 * time.Second)
+		child2   = NewBoundedAnimation(1 * time.Second)
+		child3   = NewBoundedAnimation(500 * time.Millisecond)
+		child4   = NewBoundedAnimation(500 * time.Millisecond)
+		child5   = NewBoundedAnimation(500 * time.Millisecond)
+		child6   = NewBoundedAnimation(500 * time.Millisecond)
+		expected = []time.Duration{0, 800 * time.Millisecond, 800 * time.Millisecond, 2 * time.Second, 3 * time.Second, 2700 * time.Millisecond}
+	)
+	for _, step := range []struct {
+		anim     Animator
+		position string
+	}{
+		{child1, ""},
+		{child2, "-=200ms"},
+		{child3, "<"},
+		{child4, "+=200ms"},
+		{child5, "impact"},
+		{child6, "impact-=300ms"},
+	} {
+		if step.anim == child5 {
+			if err := anim.AddLabel("impact", ">+500ms"); err != nil {
+				t.Fatalf("Timeline.AddLabel returned error %v", err)
+			}
+		}
+		if err := anim.Add(step.anim, step.position); err != nil {
+			t.Fatalf("Timeline.Add(%q) returned error %v", step.position, err)
+		}
+	}
+	for i, child := range anim.children {
+		if child.start != expected[i] {
+			t.Errorf("Child %v starts at %v, expected %v", i, child.start, expected[i])
+		}
+	}
+	if anim.Length() != 3500*time.Millisecond {
+		t.Fatalf("Timeline.Length was %v", anim.Length())
+	}
+}
+
+// Tests that children only run once the timeline reaches their position.
+func TestTimelineUpdate(t *testing.T) {
+	var (
+		done   = false
+		anim   = NewTimeline()
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(1 * time.Second)
+		resp   time.Duration
+	)
+	anim.Add(child1, "")
+	anim.Add(child2, "-=200ms")
+	anim.SetCallback(func() { done = true })
+	resp = anim.Update(500 * time.Millisecond)
+	if child1.Elapsed != 500*time.Millisecond || child2.Elapsed != 0 || resp != 0 {
+		t.Fatalf("Timeline.Update started second child too early")
+	}
+	resp = anim.Update(500 * time.Millisecond)
+	if child1.Elapsed != 1*time.Second || child2.Elapsed != 200*time.Millisecond || resp != 0 {
+		t.Fatalf("Timeline.Update did not start second child at its position")
+	}
+	if anim.IsDone() || done {
+		t.Fatalf("Timeline marked done too early")
+	}
+	resp = anim.Update(1 * time.Second)
+	if !anim.IsDone() || !done {
+		t.Fatalf("Timeline not marked done after last child")
+	}
+	if resp != 200*time.Millisecond {
+		t.Fatalf("Timeline.Update did not return remainder, got %v", resp)
+	}
+}
+
+// Tests that a timeline placing children back to back matches ChainedAnimation.
+func TestTimelineMatchesChained(t *testing.T) {
+	var (
+		timeline = NewTimeline()
+		chained  = NewChainedAnimation([]Animator{
+			NewBoundedAnimation(1 * time.Second),
+			NewBoundedAnimation(2 * time.Second),
+		}, false)
+	)
+	timeline.Add(NewBoundedAnimation(1*time.Second), "")
+	timeline.Add(NewBoundedAnimation(2*time.Second), "")
+	for _, step := range []time.Duration{500 * time.Millisecond, 600 * time.Millisecond, 500 * time.Millisecond, 1500 * time.Millisecond} {
+		var (
+			got      = timeline.Update(step)
+			expected = chained.Update(step)
+		)
+		if got != expected || timeline.IsDone() != chained.IsDone() {
+			t.Fatalf("Timeline returned %v, ChainedAnimation returned %v", got, expected)
+		}
+	}
+}
+
+// Tests that the length of continuous children is measured from their function.
+func TestTimelineContinuousChild(t *testing.T) {
+	var (
+		dest float32
+		anim = NewTimeline()
+	)
+	anim.Add(NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest), "")
+	anim.Add(NewBoundedAnimation(1*time.Second), ">")
+	if anim.Length() != 2*time.Second {
+		t.Fatalf("Timeline.Length was %v", anim.Length())
+	}
+	anim.Update(1500 * time.Millisecond)
+	if dest != 10 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if anim.Update(1*time.Second) != 500*time.Millisecond {
+		t.Fatalf("Timeline.Update did not return remainder")
+	}
+}
+
+// Tests that invalid positions are rejected.
+func TestTimelinePositionErrors(t *testing.T) {
+	var anim = NewTimeline()
+	anim.Add(NewBoundedAnimation(1*time.Second), "")
+	for _, position := range []string{"-=2s", "missing", "missing+=1s", "<bogus", "-1s"} {
+		if err := anim.Add(NewBoundedAnimation(1*time.Second), position); err == nil {
+			t.Errorf("Timeline.Add(%q) did not return an error", position)
+		}
+	}
+	if err := anim.AddLabel("a-b", ""); err == nil {
+		t.Errorf("Timeline.AddLabel accepted an invalid name")
+	}
+}
+
+func TestTimelineReset(t *testing.T) {
+	var (
+		anim  = NewTimeline()
+		child = NewBoundedAnimation(1 * time.Second)
+	)
+	anim.Add(child, "500ms")
+	anim.Update(2 * time.Second)
+	anim.Reset()
+	if anim.Elapsed != 0 || child.Elapsed != 0 || anim.IsDone() {
+		t.Fatalf("Timeline.Reset did not reset state")
+	}
+}
+
+func TestTimelineSeek(t *testing.T) {
+	var (
+		anim   = NewTimeline()
+		child1 = NewBoundedAnimation(1 * time.Second)
+		child2 = NewBoundedAnimation(1 * time.Second)
+		resp   time.Duration
+	)
+	anim.Add(child1, "")
+	anim.Add(child2, "-=200ms")
+	anim.Seek(900*time.Millisecond, SeekSilent)
+	if child1.Elapsed != 900*time.Millisecond || child2.Elapsed != 100*time.Millisecond {
+		t.Fatalf("Timeline.Seek did not position children")
+	}
+	resp = anim.Seek(2*time.Second, SeekSilent)
+	if !anim.IsDone() || resp != 200*time.Millisecond {
+		t.Fatalf("Timeline.Seek did not finish, got remainder %v", resp)
+	}
+	anim.Seek(500*time.Millisecond, SeekSilent)
+	if anim.IsDone() || child2.Elapsed != 0 || anim.Position() != 500*time.Millisecond {
+		t.Fatalf("Timeline.Seek backwards did not rewind children")
+	}
+}
+
+// Tests that a reversed timeline mirrors the positions of its children.
+func TestTimelineReverse(t *testing.T) {
+	var (
+		dest   float32
+		anim   = NewTimeline()
+		child1 = NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest)
+		child2 = NewBoundedAnimation(2 * time.Second)
+		done   = false
+	)
+	anim.Add(child1, "")
+	anim.Add(child2, "500ms")
+	anim.SetCallback(func() { done = true })
+	anim.SetDirection(Reverse)
+	anim.Reset()
+	anim.Update(1250 * time.Millisecond)
+	if child2.Elapsed != 1250*time.Millisecond || child1.Elapsed != 0 {
+		t.Fatalf("Reversed timeline started first child too early")
+	}
+	anim.Update(500 * time.Millisecond)
+	if dest != 7.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if anim.Update(1*time.Second) != 250*time.Millisecond || !done || dest != 0 {
+		t.Fatalf("Reversed timeline did not finish at the start")
+	}
+}
+
+// Tests that a ping-pong timeline plays out and back.
+func TestTimelinePingPong(t *testing.T) {
+	var (
+		dest float32
+		anim = NewTimeline()
+	)
+	anim.Add(NewContinuousAnimation(LinearFunc(1*time.Second, 0, 10), &dest), "")
+	anim.Add(NewBoundedAnimation(1*time.Second), "")
+	anim.SetDirection(PingPong)
+	anim.Reset()
+	if anim.Length() != 4*time.Second {
+		t.Fatalf("Timeline.Length was %v", anim.Length())
+	}
+	anim.Update(3250 * time.Millisecond)
+	if dest != 7.5 || anim.IsDone() {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(-500 * time.Millisecond)
+	if dest != 10 {
+		t.Fatalf("Negative update did not rewind, got %v", dest)
+	}
+	if anim.Update(2*time.Second) != 750*time.Millisecond || dest != 0 || !anim.IsDone() {
+		t.Fatalf("Ping-pong timeline did not finish after both legs")
+	}
+}
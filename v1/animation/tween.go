@@ -0,0 +1,168 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"math"
+	"time"
+)
+
+// Interpolates between two values, returning a at t == 0 and b at t == 1.
+type Lerp[T any] func(a, b T, t float64) T
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+func LerpFloat[T Float](a, b T, t float64) T {
+	return T(float64(a)*(1-t) + float64(b)*t)
+}
+
+// Interpolates integers, rounding to the nearest value.
+func LerpInteger[T Integer](a, b T, t float64) T {
+	return T(math.Round(float64(a)*(1-t) + float64(b)*t))
+}
+
+// Progress from 0 to 1 over a fixed duration along an easing curve, for use
+// with NewTween.
+func ProgressFunc(duration time.Duration, ease Easing) ContinuousFunc {
+	return EasedFunc(duration, 0, 1, ease)
+}
+
+// Animates a value of any type.  The function supplies progress, which is
+// passed to the interpolator to produce a value between From and To.
+type Tween[T any] struct {
+	Elapsed   time.Duration
+	From      T
+	To        T
+	function  ContinuousFunc
+	lerp      Lerp[T]
+	value     T
+	target    *T
+	events    *Events
+	done      bool
+	direction Direction
+	length    time.Duration
+}
+
+func NewTween[T any](f ContinuousFunc, from, to T, lerp Lerp[T], target *T) *Tween[T] {
+	return &Tween[T]{
+		From:     from,
+		To:       to,
+		function: f,
+		lerp:     lerp,
+		value:    from,
+		target:   target,
+		events:   &Events{},
+	}
+}
+
+// Negative elapsed time moves the animation back towards its start.
+func (a *Tween[T]) Update(elapsed time.Duration) time.Duration {
+	var (
+		remainder time.Duration
+		progress  float32
+	)
+	a.Elapsed = max(a.Elapsed+elapsed, 0)
+	progress, remainder = a.evaluate()
+	a.value = a.lerp(a.From, a.To, float64(progress))
+	if a.target != nil {
+		*a.target = a.value
+	}
+	a.events.update(a.done)
+	return remainder
+}
+
+func (a *Tween[T]) SetCallback(callback AnimatorCallback) {
+	a.Events().setCallback(callback)
+}
+
+func (a *Tween[T]) Events() *Events {
+	return ensureEvents(&a.events)
+}
+
+func (a *Tween[T]) IsDone() bool {
+	return a.done
+}
+
+func (a *Tween[T]) Reset() {
+	a.done = false
+	a.Elapsed = 0
+	a.value = a.From
+	a.events.reset()
+}
+
+func (a *Tween[T]) Delete() {
+	a.events.delete()
+}
+
+func (a *Tween[T]) Seek(position time.Duration, mode SeekMode) time.Duration {
+	var (
+		remainder time.Duration
+		progress  float32
+	)
+	a.Elapsed = max(position, 0)
+	progress, remainder = a.evaluate()
+	a.value = a.lerp(a.From, a.To, float64(progress))
+	if a.target != nil {
+		*a.target = a.value
+	}
+	a.events.seek(a.done, mode)
+	return remainder
+}
+
+func (a *Tween[T]) Position() time.Duration {
+	return a.Elapsed
+}
+
+func (a *Tween[T]) Length() time.Duration {
+	return passLength(ContinuousLength(a.function), a.direction)
+}
+
+// Progress functions which never finish can only play forwards.
+func (a *Tween[T]) SetDirection(direction Direction) {
+	a.direction = direction
+	a.length = ContinuousLength(a.function)
+}
+
+func (a *Tween[T]) Direction() Direction {
+	return a.direction
+}
+
+// Evaluates progress at the current position, updating done.
+func (a *Tween[T]) evaluate() (progress float32, remainder time.Duration) {
+	if a.direction == Forward || a.length <= 0 {
+		progress, a.done, remainder = a.function(a.Elapsed)
+		return
+	}
+	var (
+		pass        = passLength(a.length, a.direction)
+		position, _ = playhead(a.Elapsed, a.length, a.direction, false)
+	)
+	progress, _, _ = a.function(position)
+	a.done = a.Elapsed >= pass
+	remainder = a.Elapsed - pass
+	return
+}
+
+// Returns the most recently computed value.
+func (a *Tween[T]) Value() T {
+	return a.value
+}
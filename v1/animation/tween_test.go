@@ -0,0 +1,92 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+type point struct {
+	X, Y float64
+}
+
+func lerpPoint(a, b point, t float64) point {
+	return point{LerpFloat(a.X, b.X, t), LerpFloat(a.Y, b.Y, t)}
+}
+
+// Tests that a float64 tween produces expected values.
+func TestTweenFloat64(t *testing.T) {
+	var (
+		dest float64 = 1.0
+		anim         = NewTween(ProgressFunc(4*time.Second, EaseLinear), 10, 20, LerpFloat[float64], &dest)
+	)
+	if dest != 1.0 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(1 * time.Second)
+	if dest != 12.5 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(3 * time.Second)
+	if dest != 20 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if !anim.IsDone() {
+		t.Fatalf("Tween not marked done when finished")
+	}
+}
+
+// Tests that integer tweens round to the nearest value.
+func TestTweenInteger(t *testing.T) {
+	var (
+		dest int = 0
+		anim     = NewTween(LinearFunc(3*time.Second, 0, 1), 0, 10, LerpInteger[int], &dest)
+	)
+	anim.Update(1 * time.Second)
+	if dest != 3 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	anim.Update(1 * time.Second)
+	if dest != 7 {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+}
+
+// Tests that a tween of a custom struct uses its interpolator and easing.
+func TestTweenStruct(t *testing.T) {
+	var (
+		dest point
+		done = false
+		anim = NewTween(ProgressFunc(2*time.Second, EaseInQuad), point{0, 0}, point{8, -4}, lerpPoint, &dest)
+		resp time.Duration
+	)
+	anim.SetCallback(func() { done = true })
+	resp = anim.Update(1 * time.Second)
+	if dest != (point{2, -1}) {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	resp = anim.Update(1500 * time.Millisecond)
+	if dest != (point{8, -4}) {
+		t.Fatalf("Target value does not match expected, got %v", dest)
+	}
+	if !done || resp != 500*time.Millisecond {
+		t.Fatalf("Tween did not finish with expected remainder, got %v", resp)
+	}
+	anim.Reset()
+	if anim.Value() != (point{0, 0}) || anim.IsDone() {
+		t.Fatalf("Tween.Reset did not restore starting state")
+	}
+}
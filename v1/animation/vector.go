@@ -0,0 +1,63 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+type Vec2 struct {
+	X, Y float32
+}
+
+type Vec3 struct {
+	X, Y, Z float32
+}
+
+type Vec4 struct {
+	X, Y, Z, W float32
+}
+
+func LerpVec2(a, b Vec2, t float64) Vec2 {
+	return Vec2{
+		LerpFloat(a.X, b.X, t),
+		LerpFloat(a.Y, b.Y, t),
+	}
+}
+
+func LerpVec3(a, b Vec3, t float64) Vec3 {
+	return Vec3{
+		LerpFloat(a.X, b.X, t),
+		LerpFloat(a.Y, b.Y, t),
+		LerpFloat(a.Z, b.Z, t),
+	}
+}
+
+func LerpVec4(a, b Vec4, t float64) Vec4 {
+	return Vec4{
+		LerpFloat(a.X, b.X, t),
+		LerpFloat(a.Y, b.Y, t),
+		LerpFloat(a.Z, b.Z, t),
+		LerpFloat(a.W, b.W, t),
+	}
+}
+
+func NewVec2Animation(f ContinuousFunc, from, to Vec2, target *Vec2) *Tween[Vec2] {
+	return NewTween(f, from, to, LerpVec2, target)
+}
+
+func NewVec3Animation(f ContinuousFunc, from, to Vec3, target *Vec3) *Tween[Vec3] {
+	return NewTween(f, from, to, LerpVec3, target)
+}
+
+func NewVec4Animation(f ContinuousFunc, from, to Vec4, target *Vec4) *Tween[Vec4] {
+	return NewTween(f, from, to, LerpVec4, target)
+}
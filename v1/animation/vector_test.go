@@ -0,0 +1,48 @@
+// Copyright 2016 Pikkpoiss Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package animation
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests that vector animations interpolate every component.
+func TestVectorAnimations(t *testing.T) {
+	var (
+		v2 Vec2
+		v3 Vec3
+		v4 Vec4
+		f  = LinearFunc(2*time.Second, 0, 1)
+		a2 = NewVec2Animation(f, Vec2{0, 10}, Vec2{10, 0}, &v2)
+		a3 = NewVec3Animation(f, Vec3{0, 10, 2}, Vec3{10, 0, 4}, &v3)
+		a4 = NewVec4Animation(f, Vec4{0, 10, 2, -2}, Vec4{10, 0, 4, 2}, &v4)
+		g  = NewGroupedAnimation([]Animator{a2, a3, a4})
+	)
+	g.Update(1 * time.Second)
+	if v2 != (Vec2{5, 5}) {
+		t.Fatalf("Vec2 value does not match expected, got %v", v2)
+	}
+	if v3 != (Vec3{5, 5, 3}) {
+		t.Fatalf("Vec3 value does not match expected, got %v", v3)
+	}
+	if v4 != (Vec4{5, 5, 3, 0}) {
+		t.Fatalf("Vec4 value does not match expected, got %v", v4)
+	}
+	g.Update(1 * time.Second)
+	if v4 != (Vec4{10, 0, 4, 2}) || !g.IsDone() {
+		t.Fatalf("Vec4 value does not match expected, got %v", v4)
+	}
+}